@@ -0,0 +1,45 @@
+package stocks
+
+import (
+	"backend/internal/apierror"
+	"backend/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// GetBrokerageActions accepts the query parameters of GetStocks.
+func (h *Handler) GetBrokerageActions(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	name := strings.TrimSpace(mux.Vars(r)["name"])
+
+	if len(name) > domain.MaxFilterLength || domain.BrokerageSlug(name) == "" {
+		reason := fmt.Sprintf("must have letters or digits and be at most %d characters", domain.MaxFilterLength)
+		apierror.Write(w, r, domain.InvalidArgument("Invalid brokerage", map[string]string{"name": reason}), "Invalid brokerage")
+		return
+	}
+
+	query, err := parseStocksQuery(r)
+
+	if err != nil {
+		apierror.Write(w, r, err, "Invalid query parameters")
+		return
+	}
+
+	actions, err := h.Service.GetBrokerageActions(ctx, name, query.page, query.limit, query.filter, query.sort)
+
+	if err != nil {
+		apierror.Write(w, r, err, "Failed to fetch brokerage actions")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(actions)
+}
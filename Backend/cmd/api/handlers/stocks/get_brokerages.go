@@ -0,0 +1,24 @@
+package stocks
+
+import (
+	"backend/internal/apierror"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *Handler) GetBrokerages(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	brokerages, err := h.Service.GetBrokerages(ctx)
+
+	if err != nil {
+		apierror.Write(w, r, err, "Failed to fetch brokerages")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(brokerages)
+}
@@ -0,0 +1,33 @@
+package stocks
+
+import (
+	"backend/internal/apierror"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// GetStats serves the stats of the listing GetStocks would return for the
+// same query parameters.
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	query, err := parseStocksQuery(r)
+
+	if err != nil {
+		apierror.Write(w, r, err, "Invalid query parameters")
+		return
+	}
+
+	stats, err := h.Service.GetStats(ctx, query.limit, query.filter)
+
+	if err != nil {
+		apierror.Write(w, r, err, "Failed to fetch stocks stats")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
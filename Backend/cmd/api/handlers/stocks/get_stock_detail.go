@@ -0,0 +1,35 @@
+package stocks
+
+import (
+	"backend/internal/apierror"
+	"backend/internal/domain"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetStockDetail(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	ticker := strings.ToUpper(mux.Vars(r)["ticker"])
+
+	if reason := validateTicker(ticker); reason != "" {
+		apierror.Write(w, r, domain.InvalidArgument("Invalid ticker", map[string]string{"ticker": reason}), "Invalid ticker")
+		return
+	}
+
+	detail, err := h.Service.GetStockDetail(ctx, ticker)
+
+	if err != nil {
+		apierror.Write(w, r, err, "Failed to fetch stock detail")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
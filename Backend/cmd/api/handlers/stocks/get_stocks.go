@@ -1,73 +1,56 @@
 package stocks
 
 import (
-	"log"
+	"backend/internal/apierror"
+	"backend/internal/domain"
+	"context"
 	"net/http"
-	"strings"
 )
 
 func (h *Handler) GetStocks(w http.ResponseWriter, r *http.Request) {
 
-	var page *string
-	var filter *string
-	var ticker *string
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
 
-	queryValues := r.URL.Query()
-
-	if nextPage := queryValues.Get("next_page"); nextPage != "" {
-		page = &nextPage
-	}
-
-	if filterParam := queryValues.Get("filter"); filterParam != "" {
-		filter = &filterParam
-	}
-
-	if tickerParam := queryValues.Get("ticker"); tickerParam != "" {
-		tickerUpper := strings.ToUpper(tickerParam)
-		ticker = &tickerUpper
-	}
-
-	stats, err := h.Service.GetStats(filter, ticker)
+	query, err := parseStocksQuery(r)
 
 	if err != nil {
-		http.Error(w, "Failed to fetch stocks stats", http.StatusInternalServerError)
-		log.Println("Error fetching stocks stats:", err)
+		apierror.Write(w, r, err, "Invalid query parameters")
 		return
 	}
 
-	if ticker != nil {
-		stocks, err := h.Service.GetStockByTicker(*ticker, page, filter)
+	page, filter := query.page, query.filter
+
+	var stats *domain.StocksStats
+
+	if query.includeStats {
+		var err error
+		stats, err = h.Service.GetStats(ctx, query.limit, filter)
 
 		if err != nil {
-			http.Error(w, "Failed to get stock by ticker", http.StatusInternalServerError)
-			log.Println("Error fetching stock by ticker:", err)
+			apierror.Write(w, r, err, "Failed to fetch stocks stats")
 			return
 		}
-
-		h.Write(*stocks, *stats, w)
-		return
 	}
 
-	if filter != nil {
-		stocks, err := h.Service.GetFilterStocks(page, filter)
+	if !filter.IsZero() {
+		stocks, err := h.Service.GetFilterStocks(ctx, page, query.limit, filter, query.sort)
 
 		if err != nil {
-			http.Error(w, "Failed to get up stocks", http.StatusInternalServerError)
-			log.Println("Error fetching up stocks:", err)
+			apierror.Write(w, r, err, "Failed to get filtered stocks")
 			return
 		}
 
-		h.Write(*stocks, *stats, w)
+		h.Write(*stocks, stats, w)
 		return
 	}
 
-	stocks, err := h.Service.GetStocks(page)
+	stocks, err := h.Service.GetStocks(ctx, page, query.limit, query.sort)
 
 	if err != nil {
-		http.Error(w, "Failed to get stocks", http.StatusInternalServerError)
-		log.Println("Error fetching stocks:", err)
+		apierror.Write(w, r, err, "Failed to get stocks")
 		return
 	}
 
-	h.Write(*stocks, *stats, w)
+	h.Write(*stocks, stats, w)
 }
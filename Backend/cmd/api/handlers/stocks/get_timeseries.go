@@ -0,0 +1,82 @@
+package stocks
+
+import (
+	"backend/internal/apierror"
+	"backend/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (h *Handler) GetTimeseries(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	values := r.URL.Query()
+	details := map[string]string{}
+
+	query := domain.TimeseriesQuery{Bucket: domain.BucketDay}
+
+	if bucket := values.Get("bucket"); bucket != "" {
+		if !slices.Contains(domain.TimeseriesBuckets, bucket) {
+			details["bucket"] = "must be one of " + strings.Join(domain.TimeseriesBuckets, ", ")
+		}
+		query.Bucket = bucket
+	}
+
+	if groupBy := values.Get("group_by"); groupBy != "" {
+		if !slices.Contains(domain.TimeseriesGroups, groupBy) {
+			details["group_by"] = "must be one of " + strings.Join(domain.TimeseriesGroups, ", ")
+		}
+		query.GroupBy = groupBy
+	}
+
+	query.From = parseTime(values, "from", false, details)
+	query.To = parseTime(values, "to", true, details)
+
+	if len(details) == 0 {
+		fillTimeseriesRange(&query, time.Now())
+
+		switch from, to := *query.From, *query.To; {
+		case from.After(to):
+			details["to"] = "must not be before from"
+		case domain.CountBuckets(from, to, query.Bucket) > domain.MaxTimeseriesBuckets:
+			details["to"] = fmt.Sprintf("must be at most %d %s buckets after from", domain.MaxTimeseriesBuckets, query.Bucket)
+		}
+	}
+
+	if len(details) > 0 {
+		apierror.Write(w, r, domain.InvalidArgument("Invalid query parameters", details), "Invalid query parameters")
+		return
+	}
+
+	timeseries, err := h.Service.GetTimeseries(ctx, query)
+
+	if err != nil {
+		apierror.Write(w, r, err, "Failed to fetch time series")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(timeseries)
+}
+
+// fillTimeseriesRange sets a missing To to the end of the bucket now falls
+// in, rather than now itself, so open-ended queries share their cached
+// answer until that bucket ends. A missing From starts the widest range
+// ending at To.
+func fillTimeseriesRange(query *domain.TimeseriesQuery, now time.Time) {
+	if query.To == nil {
+		end := domain.AddBuckets(now, query.Bucket, 1).Add(-time.Nanosecond)
+		query.To = &end
+	}
+	if query.From == nil {
+		from := domain.AddBuckets(*query.To, query.Bucket, 1-domain.MaxTimeseriesBuckets)
+		query.From = &from
+	}
+}
@@ -0,0 +1,30 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestFillTimeseriesRangeIsStableWithinABucket(t *testing.T) {
+	morning := time.Date(2025, time.March, 12, 8, 0, 0, 1, time.UTC)
+	evening := time.Date(2025, time.March, 12, 23, 59, 59, 0, time.UTC)
+
+	for _, bucket := range domain.TimeseriesBuckets {
+		first := domain.TimeseriesQuery{Bucket: bucket}
+		second := domain.TimeseriesQuery{Bucket: bucket}
+
+		fillTimeseriesRange(&first, morning)
+		fillTimeseriesRange(&second, evening)
+
+		if !first.From.Equal(*second.From) || !first.To.Equal(*second.To) {
+			t.Errorf("%s: ranges %v..%v and %v..%v differ within one bucket", bucket, first.From, first.To, second.From, second.To)
+		}
+		if first.To.Before(evening) {
+			t.Errorf("%s: To = %v leaves out the rest of the current bucket", bucket, first.To)
+		}
+		if got := domain.CountBuckets(*first.From, *first.To, bucket); got != domain.MaxTimeseriesBuckets {
+			t.Errorf("%s: range spans %d buckets, want %d", bucket, got, domain.MaxTimeseriesBuckets)
+		}
+	}
+}
@@ -1,16 +1,47 @@
 package stocks
 
 import (
+	"backend/internal/apierror"
+	"backend/internal/domain"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 func (h *Handler) GetTopStocks(w http.ResponseWriter, r *http.Request) {
 
-	stocks, err := h.Service.GetTopStocks()
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	values := r.URL.Query()
+	details := map[string]string{}
+
+	limit := domain.DefaultTopLimit
+
+	if limitParam := values.Get("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > domain.MaxTopLimit {
+			details["limit"] = fmt.Sprintf("must be an integer between 1 and %d", domain.MaxTopLimit)
+		}
+	}
+
+	// Unknown strategies are reported by the scoring engine, which knows
+	// the configured ones.
+	strategy := strings.ToLower(strings.TrimSpace(values.Get("strategy")))
+
+	if len(details) > 0 {
+		apierror.Write(w, r, domain.InvalidArgument("Invalid query parameters", details), "Invalid query parameters")
+		return
+	}
+
+	stocks, err := h.Service.GetTopStocks(ctx, limit, strategy)
 
 	if err != nil {
-		http.Error(w, "Failed to fetch top stocks", http.StatusInternalServerError)
+		apierror.Write(w, r, err, "Failed to fetch top stocks")
 		return
 	}
 
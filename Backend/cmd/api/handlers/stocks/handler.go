@@ -1,11 +1,16 @@
 package stocks
 
-import "backend/internal/services/stocks"
+import (
+	"backend/internal/services/stocks"
+	"time"
+)
 
 type Handler struct {
 	Service *stocks.Service
+	// Timeout bounds the work done for a single request.
+	Timeout time.Duration
 }
 
-func NewHandler(service *stocks.Service) *Handler {
-	return &Handler{Service: service}
+func NewHandler(service *stocks.Service, timeout time.Duration) *Handler {
+	return &Handler{Service: service, Timeout: timeout}
 }
@@ -0,0 +1,207 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stocksQuery holds the validated query parameters of GetStocks. Unset
+// parameters are nil.
+type stocksQuery struct {
+	page   *string
+	filter domain.StocksFilter
+	limit  int
+	sort   domain.StocksSort
+	// includeStats is false when the client opted out of the listing
+	// stats with include_stats=false.
+	includeStats bool
+}
+
+// parseStocksQuery validates every parameter and reports all problems at
+// once, keyed by parameter name.
+func parseStocksQuery(r *http.Request) (stocksQuery, error) {
+	query := stocksQuery{limit: domain.DefaultPageSize, sort: domain.DefaultStocksSort, includeStats: true}
+	details := map[string]string{}
+
+	values := r.URL.Query()
+
+	// cursor takes next_cursor or prev_cursor from a previous response;
+	// next_page is its older name.
+	page := values.Get("cursor")
+	if page == "" {
+		page = values.Get("next_page")
+	}
+
+	if page != "" {
+		if len(page) > domain.MaxCursorLength {
+			details["cursor"] = fmt.Sprintf("must be at most %d characters", domain.MaxCursorLength)
+		}
+		query.page = &page
+	}
+
+	if filter := values.Get("filter"); filter != "" && filter != domain.StockFilterAll {
+		if !slices.Contains(domain.StockFilters, filter) {
+			details["filter"] = "must be one of " + strings.Join(domain.StockFilters, ", ")
+		}
+		query.filter.Target = filter
+	}
+
+	if tickerParam := values.Get("ticker"); tickerParam != "" {
+		ticker := strings.ToUpper(tickerParam)
+		if reason := validateTicker(ticker); reason != "" {
+			details["ticker"] = reason
+		}
+		query.filter.Ticker = ticker
+	}
+
+	text := func(name string, target *string) {
+		value := strings.TrimSpace(values.Get(name))
+		if len(value) > domain.MaxFilterLength {
+			details[name] = fmt.Sprintf("must be at most %d characters", domain.MaxFilterLength)
+		}
+		*target = value
+	}
+
+	text("brokerage", &query.filter.Brokerage)
+	text("rating_to", &query.filter.RatingTo)
+	text("company", &query.filter.Company)
+
+	canonical := func(name string, target *string) {
+		value := strings.ToLower(values.Get(name))
+		if value != "" && domain.RatingLevel(value) == 0 {
+			details[name] = "must be one of " + strings.Join(domain.CanonicalRatings, ", ")
+		}
+		*target = value
+	}
+
+	canonical("rating", &query.filter.Rating)
+	canonical("min_rating", &query.filter.MinRating)
+
+	if action := values.Get("action"); action != "" {
+		if _, ok := domain.StockActions[action]; !ok {
+			details["action"] = "must be one of " + strings.Join(domain.ActionNames(), ", ")
+		}
+		query.filter.Action = action
+	}
+
+	query.filter.From = parseTime(values, "from", false, details)
+	query.filter.To = parseTime(values, "to", true, details)
+
+	if from, to := query.filter.From, query.filter.To; from != nil && to != nil && from.After(*to) {
+		details["to"] = "must not be before from"
+	}
+
+	query.filter.MinTarget = parseFloat(values, "min_target", details)
+	query.filter.MaxTarget = parseFloat(values, "max_target", details)
+	query.filter.MinChange = parseFloat(values, "min_change", details)
+	query.filter.MaxChange = parseFloat(values, "max_change", details)
+
+	if min, max := query.filter.MinTarget, query.filter.MaxTarget; min != nil && max != nil && *min > *max {
+		details["max_target"] = "must not be below min_target"
+	}
+	if min, max := query.filter.MinChange, query.filter.MaxChange; min != nil && max != nil && *min > *max {
+		details["max_change"] = "must not be below min_change"
+	}
+
+	if limitParam := values.Get("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > domain.MaxPageSize {
+			details["limit"] = fmt.Sprintf("must be an integer between 1 and %d", domain.MaxPageSize)
+		}
+		query.limit = limit
+	}
+
+	if sortParam := values.Get("sort"); sortParam != "" {
+		if !slices.Contains(domain.StockSortFields, sortParam) {
+			details["sort"] = "must be one of " + strings.Join(domain.StockSortFields, ", ")
+		}
+		query.sort.Field = sortParam
+	}
+
+	if order := values.Get("order"); order != "" {
+		if !slices.Contains(domain.SortOrders, order) {
+			details["order"] = "must be one of " + strings.Join(domain.SortOrders, ", ")
+		}
+		query.sort.Desc = order == domain.OrderDesc
+	}
+
+	if includeStats := values.Get("include_stats"); includeStats != "" {
+		include, err := strconv.ParseBool(includeStats)
+		if err != nil {
+			details["include_stats"] = "must be true or false"
+		}
+		query.includeStats = include
+	}
+
+	if len(details) > 0 {
+		return stocksQuery{}, domain.InvalidArgument("Invalid query parameters", details)
+	}
+
+	return query, nil
+}
+
+// parseTime reads an RFC 3339 time or a YYYY-MM-DD date. A date used as
+// the end of a range covers that whole day.
+func parseTime(values url.Values, name string, endOfDay bool, details map[string]string) *time.Time {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t
+	}
+
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		details[name] = "must be an RFC 3339 time or a YYYY-MM-DD date"
+		return nil
+	}
+
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return &t
+}
+
+func parseFloat(values url.Values, name string, details map[string]string) *float64 {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		details[name] = "must be a number"
+		return nil
+	}
+
+	return &f
+}
+
+// validateTicker returns why an upper-cased ticker is rejected, or "".
+func validateTicker(ticker string) string {
+	switch {
+	case len(ticker) > domain.MaxTickerLength:
+		return fmt.Sprintf("must be at most %d characters", domain.MaxTickerLength)
+	case !domain.TickerPattern.MatchString(ticker):
+		return "must match " + domain.TickerPattern.String()
+	default:
+		return ""
+	}
+}
+
+// GetParams lists the values GetStocks accepts.
+func (h *Handler) GetParams(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(domain.NewStocksQueryParams())
+}
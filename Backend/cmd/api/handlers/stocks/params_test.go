@@ -0,0 +1,63 @@
+package stocks
+
+import (
+	"backend/internal/cursor"
+	"backend/internal/domain"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// TestCursorOfLongestFilterIsAccepted checks that a cursor issued for the
+// longest filter the API accepts fits in domain.MaxCursorLength.
+func TestCursorOfLongestFilterIsAccepted(t *testing.T) {
+	longest := strings.Repeat("é", domain.MaxFilterLength/len("é"))
+
+	values := url.Values{}
+	values.Set("filter", domain.StockFilterEqual)
+	values.Set("ticker", strings.Repeat("B", domain.MaxTickerLength))
+	values.Set("brokerage", longest)
+	values.Set("rating_to", longest)
+	values.Set("company", longest)
+	values.Set("rating", domain.RatingStrongSell)
+	values.Set("min_rating", domain.RatingStrongSell)
+	values.Set("action", "target_lowered")
+	values.Set("from", "2024-01-02T03:04:05.123456789+05:30")
+	values.Set("to", "2025-01-02T03:04:05.123456789+05:30")
+	values.Set("min_target", "-1.2345678901234567e+300")
+	values.Set("max_target", "1.2345678901234567e+300")
+	values.Set("min_change", "-1.2345678901234567e+300")
+	values.Set("max_change", "1.2345678901234567e+300")
+	values.Set("sort", domain.SortCompany)
+	values.Set("order", domain.OrderDesc)
+
+	query, err := parseStocksQuery(httptest.NewRequest("GET", "/api/v1/stocks?"+values.Encode(), nil))
+	if err != nil {
+		t.Fatalf("parseStocksQuery: %v", err)
+	}
+
+	codec := cursor.NewCodec("test")
+	scope := cursor.Scope{Sort: query.sort, Filter: query.filter.String()}
+	issued := codec.Encode(domain.Cursor{Key: longest, Ticker: query.filter.Ticker, Backward: true}, scope)
+
+	values.Set("cursor", issued)
+
+	query, err = parseStocksQuery(httptest.NewRequest("GET", "/api/v1/stocks?"+values.Encode(), nil))
+	if err != nil {
+		t.Fatalf("parseStocksQuery with a %d-character cursor: %v", len(issued), err)
+	}
+
+	decoded, err := codec.Decode(*query.page, scope)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if decoded.Key != longest || !decoded.Backward {
+		t.Fatalf("decoded = %+v, want the issued position", decoded)
+	}
+
+	other := cursor.Scope{Sort: query.sort, Filter: query.filter.String() + "&x=1"}
+	if _, err := codec.Decode(issued, other); err == nil {
+		t.Fatal("cursor decoded under another filter")
+	}
+}
@@ -0,0 +1,55 @@
+package stocks
+
+import (
+	"backend/internal/apierror"
+	"backend/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	values := r.URL.Query()
+	details := map[string]string{}
+
+	query := strings.TrimSpace(values.Get("q"))
+
+	switch {
+	case query == "":
+		details["q"] = "is required"
+	case len(query) > domain.MaxSearchLength:
+		details["q"] = fmt.Sprintf("must be at most %d characters", domain.MaxSearchLength)
+	}
+
+	limit := domain.DefaultSearchLimit
+
+	if limitParam := values.Get("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > domain.MaxSearchLimit {
+			details["limit"] = fmt.Sprintf("must be an integer between 1 and %d", domain.MaxSearchLimit)
+		}
+	}
+
+	if len(details) > 0 {
+		apierror.Write(w, r, domain.InvalidArgument("Invalid query parameters", details), "Invalid query parameters")
+		return
+	}
+
+	suggestions, err := h.Service.Search(ctx, query, limit)
+
+	if err != nil {
+		apierror.Write(w, r, err, "Failed to search stocks")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suggestions)
+}
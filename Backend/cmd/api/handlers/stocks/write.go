@@ -6,11 +6,19 @@ import (
 	"net/http"
 )
 
-func (h *Handler) Write(stockPage domain.StocksPage, stats domain.StocksStats, w http.ResponseWriter) error {
+func (h *Handler) Write(stockPage domain.StocksPage, stats *domain.StocksStats, w http.ResponseWriter) error {
 	response := domain.ApiResponse{
-		Items:      stockPage.Items,
-		Stats:      stats,
-		NextCursor: &stockPage.NextPage,
+		Items:   stockPage.Items,
+		Stats:   stats,
+		HasMore: stockPage.NextPage != "",
+	}
+
+	if stockPage.NextPage != "" {
+		response.NextCursor = &stockPage.NextPage
+	}
+
+	if stockPage.PrevPage != "" {
+		response.PrevCursor = &stockPage.PrevPage
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,24 @@
+package sync
+
+import (
+	"backend/internal/apierror"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	status, err := h.Scheduler.Status(ctx)
+
+	if err != nil {
+		apierror.Write(w, r, err, "Failed to fetch sync status")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,16 @@
+package sync
+
+import (
+	"backend/internal/services/sync"
+	"time"
+)
+
+type Handler struct {
+	Scheduler *sync.Scheduler
+	// Timeout bounds the work done for a single request.
+	Timeout time.Duration
+}
+
+func NewHandler(scheduler *sync.Scheduler, timeout time.Duration) *Handler {
+	return &Handler{Scheduler: scheduler, Timeout: timeout}
+}
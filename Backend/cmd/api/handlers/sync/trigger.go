@@ -0,0 +1,47 @@
+package sync
+
+import (
+	"backend/internal/apierror"
+	"backend/internal/services/sync"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+func (h *Handler) Trigger(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	// resume=false forces a full sync from the first provider page.
+	resume := r.URL.Query().Get("resume") != "false"
+
+	err := h.Scheduler.Trigger(resume)
+
+	if errors.Is(err, sync.ErrRunInProgress) {
+		apierror.Write(w, r, err, "Sync already running")
+		return
+	}
+
+	if errors.Is(err, sync.ErrStopped) {
+		apierror.Write(w, r, err, "Server is shutting down")
+		return
+	}
+
+	if err != nil {
+		apierror.Write(w, r, err, "Failed to start sync")
+		return
+	}
+
+	status, err := h.Scheduler.Status(ctx)
+
+	if err != nil {
+		apierror.Write(w, r, err, "Failed to fetch sync status")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(status)
+}
@@ -2,18 +2,33 @@ package main
 
 import (
 	stocksHanlder "backend/cmd/api/handlers/stocks"
+	syncHandler "backend/cmd/api/handlers/sync"
 	"backend/cmd/api/router"
+	"backend/internal/cache"
 	"backend/internal/config"
+	"backend/internal/cursor"
+	"backend/internal/ports"
 	"backend/internal/provider/stock"
 	"backend/internal/provider/stock/client"
+	"backend/internal/ratelimit"
+	CacheRepository "backend/internal/repository/cache/stocks"
 	"backend/internal/repository/cockroachdb"
+	CheckpointsRepository "backend/internal/repository/cockroachdb/checkpoints"
 	StocksRepository "backend/internal/repository/cockroachdb/stocks"
+	SyncRunsRepository "backend/internal/repository/cockroachdb/syncruns"
 	LoggerRepository "backend/internal/repository/logger/stocks"
+	"backend/internal/schedule"
+	"backend/internal/scoring"
 	stockService "backend/internal/services/stocks"
 	"backend/internal/services/sync"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/joho/godotenv"
 )
@@ -43,34 +58,122 @@ func main() {
 	}
 
 	stockRepo := StocksRepository.NewRepository(db)
-	logRepo := LoggerRepository.NewLoggerRepository(stockRepo)
 
-	providerClient := client.NewClient(ctg.ProviderURL, ctg.Autorization)
+	var readRepo ports.StocksRepository = stockRepo
+	var cacheRepo *CacheRepository.Repository
+
+	if ctg.CacheSize > 0 {
+		cacheRepo = CacheRepository.NewCacheRepository(stockRepo, cache.NewLRU(ctg.CacheSize), ctg.CacheTTL)
+		readRepo = cacheRepo
+	}
+
+	logRepo := LoggerRepository.NewLoggerRepository(readRepo)
+
+	providerClient := client.NewClient(ctg.ProviderURL, ctg.Autorization, client.RetryPolicy{
+		MaxRetries: ctg.ProviderMaxRetries,
+		BaseDelay:  ctg.ProviderRetryBaseDelay,
+		MaxDelay:   ctg.ProviderRetryMaxDelay,
+	}, ratelimit.New(ctg.ProviderRateLimit, ctg.ProviderBurst))
 	provider := stock.NewProvider(providerClient)
 
-	syncService := sync.NewService(provider, logRepo, ctg.Workers, ctg.BatchSize)
-	service := stockService.NewService(provider, logRepo)
-	hanlder := stocksHanlder.NewHandler(service)
+	checkpointsRepo := CheckpointsRepository.NewRepository(db)
+	syncService := sync.NewService(provider, logRepo, checkpointsRepo, ctg.Workers, ctg.BatchSize)
+	syncRunsRepo := SyncRunsRepository.NewRepository(db)
+	if ctg.CursorSecret == "" {
+		log.Println("CURSOR_SECRET is not set, pagination cursors will not survive a restart")
+	}
 
-	router := router.NewRouter(hanlder)
+	scoringConfig, err := scoring.LoadConfig(ctg.ScoringConfig)
 
-	go func() {
-		log.Printf("[SYNC] starting (workers=%d, batchSize=%d)", ctg.Workers, ctg.BatchSize)
-		if err := syncService.Run(); err != nil {
-			log.Printf("[SYNC] failed: %v", err)
-			return
+	if err != nil {
+		log.Fatalf("Error loading the scoring config: %v", err)
+	}
+
+	service := stockService.NewService(provider, logRepo, cursor.NewCodec(ctg.CursorSecret), scoring.NewEngine(scoringConfig))
+	hanlder := stocksHanlder.NewHandler(service, ctg.RequestTimeout)
+
+	// The cache goes first so stats and rankings are refreshed from fresh
+	// data. The refreshes are skipped when a shutdown cancels the run.
+	if cacheRepo != nil {
+		syncService.AfterRun(cacheRepo.Invalidate)
+	}
+	syncService.RefreshAfterRun(service.RefreshStats)
+	syncService.RefreshAfterRun(service.RefreshTopStocks)
+
+	var syncSchedule schedule.Schedule
+
+	switch {
+	case ctg.SyncCron != "":
+		syncSchedule, err = schedule.ParseCron(ctg.SyncCron)
+		if err != nil {
+			log.Fatalf("Error parsing SYNC_CRON: %v", err)
 		}
-		log.Printf("[SYNC] finished successfully")
-	}()
+	case ctg.SyncInterval > 0:
+		syncSchedule = schedule.Every(ctg.SyncInterval)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	scheduler := sync.NewScheduler(syncService, syncRunsRepo, syncSchedule, ctg.SyncResume)
+	scheduler.Start(ctx)
+
+	router := router.NewRouter(hanlder, syncHandler.NewHandler(scheduler, ctg.RequestTimeout), ctg.SyncToken, syncService.DataVersion)
 
 	port := ":" + ctg.Port
 
 	fmt.Printf("Servidor montado en localhost%s\n", port)
 
-	server := http.ListenAndServe(port, router)
+	server := &http.Server{
+		Addr:         port,
+		Handler:      router,
+		ReadTimeout:  ctg.HTTPReadTimeout,
+		WriteTimeout: ctg.HTTPWriteTimeout,
+		IdleTimeout:  ctg.HTTPIdleTimeout,
+	}
+
+	serverErr := make(chan error, 1)
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	// Set when the server stopped on its own, to exit non-zero after the
+	// cleanup below.
+	var failed error
+
+	select {
+	case <-ctx.Done():
+		log.Printf("Shutting down (deadline %s)", ctg.ShutdownTimeout)
+	case err := <-serverErr:
+		log.Printf("Server failed: %v", err)
+		failed = err
+	}
+
+	// Cancelling the signal context stops the running sync once its in-flight
+	// upserts finish. Everything below shares the shutdown deadline; the pool
+	// is closed last by the deferred db.Close.
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ctg.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error draining HTTP requests: %v", err)
+	}
+
+	if err := scheduler.Wait(shutdownCtx); err != nil {
+		log.Printf("Error waiting for the sync to stop: %v", err)
+	}
 
-	if server != nil {
-		panic(server)
+	if failed != nil {
+		// log.Fatalf skips the deferred calls.
+		cancel()
+		db.Close()
+		log.Fatalf("Shutdown complete after the server failed: %v", failed)
 	}
 
+	log.Printf("Shutdown complete")
 }
@@ -2,28 +2,61 @@ package router
 
 import (
 	"backend/cmd/api/handlers/stocks"
+	"backend/cmd/api/handlers/sync"
+	"backend/internal/apierror"
 	"backend/internal/middleware"
 	"net/http"
 
 	"github.com/gorilla/mux"
 )
 
-func NewRouter(handler *stocks.Handler) *mux.Router {
+// Routes are registered on the root router with full paths: mux turns a
+// method mismatch inside a prefix subrouter into a 404 as soon as another
+// route shares the prefix, and clients should get a 405.
+const v1 = "/api/v1"
+
+// dataVersion changes whenever the stocks behind the read endpoints do; it
+// keys their ETags.
+func NewRouter(handler *stocks.Handler, syncHandler *sync.Handler, syncToken string, dataVersion func() string) *mux.Router {
 
 	r := mux.NewRouter()
 
-	api := r.PathPrefix("/api").Subrouter()
+	// Read endpoints answer with an ETag and Cache-Control; the sync status
+	// changes during a run and is left out.
+	cache := middleware.Cache(dataVersion)
+	cached := func(fn http.HandlerFunc) http.Handler {
+		return cache(fn)
+	}
+
+	r.Handle(v1+"/stocks", cached(handler.GetStocks)).Methods(http.MethodGet, http.MethodOptions)
+	r.Handle(v1+"/stocks/params", cached(handler.GetParams)).Methods(http.MethodGet, http.MethodOptions)
+	r.Handle(v1+"/stocks/stats", cached(handler.GetStats)).Methods(http.MethodGet, http.MethodOptions)
+	r.Handle(v1+"/stocks/top", cached(handler.GetTopStocks)).Methods(http.MethodGet, http.MethodOptions)
+	// After the fixed /stocks paths, which would otherwise match {ticker}.
+	r.Handle(v1+"/stocks/{ticker}", cached(handler.GetStockDetail)).Methods(http.MethodGet, http.MethodOptions)
+	r.Handle(v1+"/stocks/{ticker}/history", cached(handler.GetStockHistory)).Methods(http.MethodGet, http.MethodOptions)
+
+	r.Handle(v1+"/search", cached(handler.Search)).Methods(http.MethodGet, http.MethodOptions)
+	r.Handle(v1+"/stats/timeseries", cached(handler.GetTimeseries)).Methods(http.MethodGet, http.MethodOptions)
 
-	v1 := api.PathPrefix("/v1").Subrouter()
+	r.Handle(v1+"/brokerages", cached(handler.GetBrokerages)).Methods(http.MethodGet, http.MethodOptions)
+	r.Handle(v1+"/brokerages/{name}/actions", cached(handler.GetBrokerageActions)).Methods(http.MethodGet, http.MethodOptions)
 
-	v1.HandleFunc("/stocks", handler.GetStocks).Methods(http.MethodGet, http.MethodOptions)
-	v1.HandleFunc("/stocks/top", handler.GetTopStocks).Methods(http.MethodGet, http.MethodOptions)
+	r.HandleFunc(v1+"/sync/status", syncHandler.GetStatus).Methods(http.MethodGet, http.MethodOptions)
 
-	api.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	requireToken := middleware.RequireToken(syncToken)
+	r.Handle(v1+"/sync", requireToken(http.HandlerFunc(syncHandler.Trigger))).Methods(http.MethodPost, http.MethodOptions)
+
+	r.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("Hello World"))
 	})
 
+	// Misses skip the Use chain, hence the RequestID wrapping.
+	r.NotFoundHandler = middleware.RequestID(apierror.NotFoundHandler())
+	r.MethodNotAllowedHandler = middleware.RequestID(apierror.MethodNotAllowedHandler())
+
+	r.Use(middleware.RequestID)
 	r.Use(middleware.CORS)
 
 	return r
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"backend/internal/provider/stock/fakeprovider"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+func main() {
+
+	var opts fakeprovider.Options
+
+	addr := flag.String("addr", ":8081", "address to listen on")
+	random := flag.Bool("random", false, "seed the data with the current time instead of -seed")
+
+	flag.IntVar(&opts.Pages, "pages", 20, "number of pages to serve")
+	flag.IntVar(&opts.PageSize, "page-size", 10, "stocks per page")
+	flag.Uint64Var(&opts.Seed, "seed", 1, "seed for the generated data and injected faults")
+	flag.DurationVar(&opts.Latency, "latency", 0, "delay added to every response")
+	flag.Float64Var(&opts.RateLimitRate, "rate-limit-rate", 0, "probability of answering 429")
+	flag.Float64Var(&opts.ServerErrorRate, "error-rate", 0, "probability of answering 500")
+	flag.Float64Var(&opts.MalformedRate, "malformed-rate", 0, "probability of answering invalid JSON")
+	flag.DurationVar(&opts.RetryAfter, "retry-after", 0, "Retry-After sent with 429 answers")
+	flag.IntVar(&opts.LoopAt, "loop-at", 0, "page whose next_page points back to the second page")
+	flag.StringVar(&opts.Token, "token", "", "bearer token clients must send")
+
+	flag.Parse()
+
+	if *random {
+		opts.Seed = uint64(time.Now().UnixNano())
+	}
+
+	server := fakeprovider.New(opts)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("[FAKEPROVIDER] %s %s", r.Method, r.URL.RequestURI())
+		server.ServeHTTP(w, r)
+	}))
+
+	fmt.Printf("Fake provider serving %d pages of %d stocks (seed %d) on %s\n", opts.Pages, opts.PageSize, opts.Seed, *addr)
+
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		log.Fatalf("Fake provider failed: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"backend/internal/config"
+	"backend/internal/repository/cockroachdb"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/joho/godotenv"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  up          apply every pending migration
+  down [n]    revert the last n applied migrations (default 1)
+  status      list migrations and whether they are applied`
+
+func main() {
+
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	err := godotenv.Load()
+
+	if err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	ctg := config.Load()
+
+	db, err := cockroachdb.ConnectDB(&ctg.DSN)
+
+	if err != nil {
+		log.Fatalf("Error connecting to the database: %v", err)
+	}
+
+	defer db.Close()
+
+	switch os.Args[1] {
+	case "up":
+		err = cockroachdb.Migrate(db)
+
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps <= 0 {
+				log.Fatalf("Invalid number of steps %q", os.Args[2])
+			}
+		}
+		err = cockroachdb.MigrateDown(db, steps)
+
+	case "status":
+		var status []cockroachdb.MigrationStatus
+		status, err = cockroachdb.GetMigrationStatus(db)
+		for _, migration := range status {
+			state := "pending"
+			if migration.AppliedAt != nil {
+				state = "applied " + migration.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d  %-30s %s\n", migration.Version, migration.Name, state)
+		}
+
+	default:
+		fmt.Println(usage)
+		db.Close()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		db.Close()
+		log.Fatalf("Error running migrate %s: %v", os.Args[1], err)
+	}
+}
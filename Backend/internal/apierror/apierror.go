@@ -0,0 +1,112 @@
+// Package apierror writes the JSON error envelope returned by every
+// endpoint.
+package apierror
+
+import (
+	"backend/internal/domain"
+	"backend/internal/requestid"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+)
+
+const (
+	CodeInvalidArgument     = "invalid_argument"
+	CodeNotFound            = "not_found"
+	CodeMethodNotAllowed    = "method_not_allowed"
+	CodeConflict            = "conflict"
+	CodeUnauthorized        = "unauthorized"
+	CodeUpstreamUnavailable = "upstream_unavailable"
+	CodeTimeout             = "timeout"
+	CodeCanceled            = "canceled"
+	CodeInternal            = "internal"
+)
+
+// StatusClientClosedRequest is the non-standard status used when the
+// client went away before the response was ready.
+const StatusClientClosedRequest = 499
+
+type Response struct {
+	Code      string            `json:"code"`
+	Message   string            `json:"message"`
+	RequestID string            `json:"request_id,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+// Write maps err to a status and code and writes the envelope. fallback is
+// the message used for errors without a client-facing one; those are logged
+// since their text is not sent.
+func Write(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+
+	status, code := classify(err)
+
+	response := Response{
+		Code:      code,
+		Message:   fallback,
+		RequestID: requestid.FromContext(r.Context()),
+	}
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		response.Message = domainErr.Message
+		response.Details = domainErr.Details
+	}
+
+	if status >= http.StatusInternalServerError {
+		log.Printf("[API][%s] %s: %v", response.RequestID, fallback, err)
+	}
+
+	WriteResponse(w, status, response)
+}
+
+// WriteResponse writes an already built envelope.
+func WriteResponse(w http.ResponseWriter, status int, response Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+func classify(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidArgument):
+		return http.StatusBadRequest, CodeInvalidArgument
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound, CodeNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict, CodeConflict
+	case errors.Is(err, domain.ErrUnauthorized):
+		return http.StatusUnauthorized, CodeUnauthorized
+	case errors.Is(err, domain.ErrUpstreamUnavailable):
+		return http.StatusServiceUnavailable, CodeUpstreamUnavailable
+	case errors.Is(err, domain.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, CodeTimeout
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest, CodeCanceled
+	default:
+		return http.StatusInternalServerError, CodeInternal
+	}
+}
+
+// NotFoundHandler and MethodNotAllowedHandler answer the router misses
+// with the same envelope as the handlers.
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteResponse(w, http.StatusNotFound, Response{
+			Code:      CodeNotFound,
+			Message:   "No route for " + r.URL.Path,
+			RequestID: requestid.FromContext(r.Context()),
+		})
+	})
+}
+
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteResponse(w, http.StatusMethodNotAllowed, Response{
+			Code:      CodeMethodNotAllowed,
+			Message:   "Method " + r.Method + " not allowed on " + r.URL.Path,
+			RequestID: requestid.FromContext(r.Context()),
+		})
+	})
+}
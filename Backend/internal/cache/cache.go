@@ -0,0 +1,19 @@
+// Package cache holds the stores behind the read cache: an in-process LRU
+// and an adapter for Redis. Values are opaque encoded bytes so every
+// backend can hold them.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Backend stores values by key. Get reports a miss with ok false, whether
+// the key was never set, expired or evicted. A ttl of zero or less keeps
+// the value until it is evicted or cleared.
+type Backend interface {
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Clear drops every value.
+	Clear(ctx context.Context) error
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+
+	c.Set(ctx, "a", []byte("1"), 0)
+	c.Set(ctx, "b", []byte("2"), 0)
+
+	if _, ok, _ := c.Get(ctx, "a"); !ok {
+		t.Fatal("a missing before eviction")
+	}
+
+	c.Set(ctx, "c", []byte("3"), 0)
+
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Fatal("b kept, want it evicted as least recently used")
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := c.Get(ctx, key); !ok {
+			t.Fatalf("%s evicted, want it kept", key)
+		}
+	}
+}
+
+func TestLRUExpiresAndClears(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+
+	c := NewLRU(10)
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "short", []byte("1"), time.Minute)
+	c.Set(ctx, "long", []byte("2"), time.Hour)
+
+	now = now.Add(time.Minute)
+
+	if _, ok, _ := c.Get(ctx, "short"); ok {
+		t.Fatal("short served after its ttl")
+	}
+
+	value, ok, _ := c.Get(ctx, "long")
+	if !ok || string(value) != "2" {
+		t.Fatalf("long = %q, %t; want 2", value, ok)
+	}
+
+	c.Clear(ctx)
+
+	if _, ok, _ := c.Get(ctx, "long"); ok || c.Len() != 0 {
+		t.Fatalf("long served after Clear, %d values left", c.Len())
+	}
+}
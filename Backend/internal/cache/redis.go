@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// RedisClient is the part of a Redis client the Redis backend needs. Wrap
+// the client of your choice in it; Get reports a missing key with ok false
+// rather than an error.
+type RedisClient interface {
+	Get(ctx context.Context, key string) (value string, ok bool, err error)
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// Redis is a Backend shared by every instance pointing at the same Redis.
+// Keys live under Prefix and a generation number; Clear bumps the
+// generation rather than deleting keys, so the old values are no longer
+// read and expire on their own. Set them with a ttl for that to happen.
+type Redis struct {
+	Client RedisClient
+	Prefix string
+}
+
+func NewRedis(client RedisClient, prefix string) *Redis {
+	return &Redis{Client: client, Prefix: prefix}
+}
+
+func (c *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	generation, err := c.generation(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	value, ok, err := c.Client.Get(ctx, c.key(generation, key))
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return []byte(value), true, nil
+}
+
+func (c *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	generation, err := c.generation(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.Client.Set(ctx, c.key(generation, key), string(value), max(ttl, 0))
+}
+
+func (c *Redis) Clear(ctx context.Context) error {
+	_, err := c.Client.Incr(ctx, c.Prefix+"generation")
+	return err
+}
+
+func (c *Redis) generation(ctx context.Context) (string, error) {
+	generation, ok, err := c.Client.Get(ctx, c.Prefix+"generation")
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "0", nil
+	}
+
+	if _, err := strconv.ParseInt(generation, 10, 64); err != nil {
+		return "", err
+	}
+
+	return generation, nil
+}
+
+func (c *Redis) key(generation, key string) string {
+	return c.Prefix + generation + ":" + key
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
@@ -14,6 +15,37 @@ type Config struct {
 	Workers      int
 	BatchSize    int
 	FrontendURL  string
+	// RequestTimeout is the deadline handlers give each request.
+	RequestTimeout time.Duration
+	// HTTP server timeouts and how long shutdown may take to drain requests
+	// and let the running sync stop.
+	HTTPReadTimeout  time.Duration
+	HTTPWriteTimeout time.Duration
+	HTTPIdleTimeout  time.Duration
+	ShutdownTimeout  time.Duration
+	// SyncInterval or SyncCron repeat the sync, SyncCron winning when both
+	// are set. With neither it runs once at startup.
+	SyncInterval time.Duration
+	SyncCron     string
+	SyncToken    string
+	SyncResume   bool
+	// CursorSecret signs pagination cursors. When empty a random secret is
+	// used and cursors stop working across restarts.
+	CursorSecret string
+	// ScoringConfig is the path of the JSON file tuning the top-stocks
+	// scoring strategies. When empty the built-in defaults are used.
+	ScoringConfig string
+	// CacheSize is how many repository answers the read cache holds; zero
+	// turns the cache off. CacheTTL bounds how long one is served when no
+	// sync invalidates it.
+	CacheSize int
+	CacheTTL  time.Duration
+
+	ProviderMaxRetries     int
+	ProviderRetryBaseDelay time.Duration
+	ProviderRetryMaxDelay  time.Duration
+	ProviderRateLimit      float64
+	ProviderBurst          int
 }
 
 func getenvInt(key string, def int) int {
@@ -28,6 +60,42 @@ func getenvInt(key string, def int) int {
 	return n
 }
 
+func getenvFloat(key string, def float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return def
+	}
+	return f
+}
+
+func getenvBool(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return def
+	}
+	return b
+}
+
+func getenvDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return def
+	}
+	return d
+}
+
 func Load() *Config {
 	providerURL := os.Getenv("API_ENDPOINT")
 
@@ -39,12 +107,31 @@ func Load() *Config {
 	}
 
 	return &Config{
-		DSN:          os.Getenv("CONNECTION_STRING"),
-		ProviderURL:  providerURL,
-		Autorization: authorization,
-		Port:         os.Getenv("PORT"),
-		Workers:      getenvInt("WORKERS", 5),
-		BatchSize:    getenvInt("BATCH_SIZE", 200),
-		FrontendURL:  os.Getenv("FRONTEND_URL"),
+		DSN:              os.Getenv("CONNECTION_STRING"),
+		ProviderURL:      providerURL,
+		Autorization:     authorization,
+		Port:             os.Getenv("PORT"),
+		Workers:          getenvInt("WORKERS", 5),
+		BatchSize:        getenvInt("BATCH_SIZE", 200),
+		FrontendURL:      os.Getenv("FRONTEND_URL"),
+		RequestTimeout:   getenvDuration("REQUEST_TIMEOUT", 5*time.Second),
+		HTTPReadTimeout:  getenvDuration("HTTP_READ_TIMEOUT", 10*time.Second),
+		HTTPWriteTimeout: getenvDuration("HTTP_WRITE_TIMEOUT", 15*time.Second),
+		HTTPIdleTimeout:  getenvDuration("HTTP_IDLE_TIMEOUT", 60*time.Second),
+		ShutdownTimeout:  getenvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+		SyncInterval:     getenvDuration("SYNC_INTERVAL", 0),
+		SyncCron:         strings.TrimSpace(os.Getenv("SYNC_CRON")),
+		SyncToken:        strings.TrimSpace(os.Getenv("SYNC_TOKEN")),
+		SyncResume:       getenvBool("SYNC_RESUME", true),
+		CursorSecret:     os.Getenv("CURSOR_SECRET"),
+		ScoringConfig:    strings.TrimSpace(os.Getenv("SCORING_CONFIG")),
+		CacheSize:        getenvInt("CACHE_SIZE", 1000),
+		CacheTTL:         getenvDuration("CACHE_TTL", time.Hour),
+
+		ProviderMaxRetries:     getenvInt("PROVIDER_MAX_RETRIES", 3),
+		ProviderRetryBaseDelay: getenvDuration("PROVIDER_RETRY_BASE_DELAY", 500*time.Millisecond),
+		ProviderRetryMaxDelay:  getenvDuration("PROVIDER_RETRY_MAX_DELAY", 10*time.Second),
+		ProviderRateLimit:      getenvFloat("PROVIDER_RATE_LIMIT", 5),
+		ProviderBurst:          getenvInt("PROVIDER_BURST", 5),
 	}
 }
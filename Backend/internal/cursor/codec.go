@@ -0,0 +1,113 @@
+// Package cursor turns repository positions into the opaque, signed
+// strings handed to API clients.
+package cursor
+
+import (
+	"backend/internal/domain"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// Scope is what a listing was asked for. A cursor only decodes under the
+// scope it was issued for, so it can't be replayed with other filters.
+type Scope struct {
+	Sort domain.StocksSort
+	// Filter is the canonical form of the listing's domain.StocksFilter.
+	Filter string
+	// Listing names what is paged when it is not the stocks listing, such
+	// as the actions of one brokerage.
+	Listing string
+}
+
+// payload carries a digest of the scope rather than the scope itself, so
+// the longest filters still fit in domain.MaxCursorLength.
+type payload struct {
+	Scope    string `json:"s"`
+	Key      string `json:"k"`
+	Ticker   string `json:"t"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// Codec signs cursors with HMAC-SHA256.
+type Codec struct {
+	secret []byte
+}
+
+// NewCodec builds a codec. With an empty secret it uses a random one, so
+// cursors stop working when the process restarts.
+func NewCodec(secret string) *Codec {
+	key := []byte(secret)
+
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		rand.Read(key)
+	}
+
+	return &Codec{secret: key}
+}
+
+// Encode returns "<payload>.<signature>", both base64url without padding.
+func (c *Codec) Encode(cursor domain.Cursor, scope Scope) string {
+	data, _ := json.Marshal(payload{
+		Scope:    scope.digest(),
+		Key:      cursor.Key,
+		Ticker:   cursor.Ticker,
+		Backward: cursor.Backward,
+	})
+
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+// Decode verifies and parses a cursor from Encode. It fails with
+// domain.ErrInvalidArgument when the string was not issued by this codec or
+// was issued for another scope.
+func (c *Codec) Decode(raw string, scope Scope) (*domain.Cursor, error) {
+	invalid := func(reason string) error {
+		return domain.InvalidArgument("Invalid cursor", map[string]string{"cursor": reason})
+	}
+
+	encoded, signature, ok := strings.Cut(raw, ".")
+	if !ok {
+		return nil, invalid("is not a cursor returned by this API")
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(encoded)) {
+		return nil, invalid("is not a cursor returned by this API")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, invalid("is not a cursor returned by this API")
+	}
+
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, invalid("is not a cursor returned by this API")
+	}
+
+	if p.Scope != scope.digest() {
+		return nil, invalid("was issued for different sort, order or filter parameters")
+	}
+
+	return &domain.Cursor{Sort: scope.Sort, Key: p.Key, Ticker: p.Ticker, Backward: p.Backward}, nil
+}
+
+// digest is a truncated SHA-256 of the scope, base64url without padding.
+func (s Scope) digest() string {
+	data, _ := json.Marshal([]any{s.Sort.Field, s.Sort.Desc, s.Filter, s.Listing})
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+func (c *Codec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
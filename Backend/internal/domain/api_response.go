@@ -1,7 +1,11 @@
 package domain
 
 type ApiResponse struct {
-	Items      []Stock     `json:"items"`
-	Stats      StocksStats `json:"stats"`
-	NextCursor *string     `json:"next_cursor,omitempty"`
+	Items []Stock `json:"items"`
+	// Stats is left out when the client asked for include_stats=false.
+	Stats      *StocksStats `json:"stats,omitempty"`
+	NextCursor *string      `json:"next_cursor,omitempty"`
+	PrevCursor *string      `json:"prev_cursor,omitempty"`
+	// HasMore reports whether NextCursor leads to more stocks.
+	HasMore bool `json:"has_more"`
 }
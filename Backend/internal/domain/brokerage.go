@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Brokerage is one entry of the brokerage directory the sync maintains.
+// Spellings that differ only in case or punctuation share a slug; Name is
+// the newest spelling seen.
+type Brokerage struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	// FirstSeenAt and LastSeenAt are the times of its oldest and newest
+	// rating events.
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// BrokerageSummary is a brokerage with statistics over all its events.
+type BrokerageSummary struct {
+	Brokerage
+	Actions    int `json:"actions"`
+	Tickers    int `json:"tickers"`
+	Upgrades   int `json:"upgrades"`
+	Downgrades int `json:"downgrades"`
+	// UpgradeDowngradeRatio is nil for brokerages that never downgraded.
+	UpgradeDowngradeRatio *float64 `json:"upgrade_downgrade_ratio"`
+	// AvgTargetChange is the mean target change in percent of the events
+	// that have one.
+	AvgTargetChange *float64 `json:"avg_target_change"`
+}
+
+// BrokerageActions is a page of the latest action of a brokerage on each
+// ticker it covers.
+type BrokerageActions struct {
+	Brokerage  Brokerage `json:"brokerage"`
+	Items      []Stock   `json:"items"`
+	NextCursor *string   `json:"next_cursor,omitempty"`
+	PrevCursor *string   `json:"prev_cursor,omitempty"`
+	HasMore    bool      `json:"has_more"`
+}
+
+// BrokerageSlug is the directory key of a brokerage name: lower case ASCII
+// letters and digits with dashes between the words, e.g.
+// "jpmorgan-chase-co" for "JPMorgan Chase & Co.".
+func BrokerageSlug(name string) string {
+	var b strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(name) {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+
+	return b.String()
+}
+
+// BrokeragesOf collects the brokerages of a batch of events, one per slug,
+// with the spelling of the newest event.
+func BrokeragesOf(stocks []Stock) []Brokerage {
+	var brokerages []Brokerage
+	index := map[string]int{}
+
+	for _, s := range stocks {
+		slug := BrokerageSlug(s.Brokerage)
+		if slug == "" {
+			continue
+		}
+
+		i, ok := index[slug]
+		if !ok {
+			index[slug] = len(brokerages)
+			brokerages = append(brokerages, Brokerage{Slug: slug, Name: s.Brokerage, FirstSeenAt: s.Time, LastSeenAt: s.Time})
+			continue
+		}
+
+		b := &brokerages[i]
+		if s.Time.Before(b.FirstSeenAt) {
+			b.FirstSeenAt = s.Time
+		}
+		if !s.Time.Before(b.LastSeenAt) {
+			b.LastSeenAt = s.Time
+			b.Name = s.Brokerage
+		}
+	}
+
+	return brokerages
+}
+
+// UpgradeDowngradeRatio is upgrades over downgrades, or nil without
+// downgrades.
+func UpgradeDowngradeRatio(upgrades, downgrades int) *float64 {
+	if downgrades == 0 {
+		return nil
+	}
+
+	ratio := float64(upgrades) / float64(downgrades)
+	return &ratio
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds shared by every layer. Handlers map them to HTTP statuses, so
+// services and repositories wrap them instead of inventing their own.
+var (
+	ErrInvalidArgument     = errors.New("invalid argument")
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	ErrTimeout             = errors.New("timeout")
+)
+
+// Error is an error of a given kind with a message meant for API clients.
+// Details carries optional field-level information.
+type Error struct {
+	Kind    error
+	Message string
+	Details map[string]string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+func InvalidArgument(message string, details map[string]string) error {
+	return &Error{Kind: ErrInvalidArgument, Message: message, Details: details}
+}
+
+func NotFound(message string) error {
+	return &Error{Kind: ErrNotFound, Message: message}
+}
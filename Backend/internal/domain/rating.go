@@ -0,0 +1,100 @@
+package domain
+
+import "strings"
+
+// The canonical rating scale every brokerage vocabulary is mapped onto,
+// from the most bearish to the most bullish.
+const (
+	RatingStrongSell = "strong_sell"
+	RatingSell       = "sell"
+	RatingHold       = "hold"
+	RatingBuy        = "buy"
+	RatingStrongBuy  = "strong_buy"
+)
+
+// CanonicalRatings lists the scale in order; a rating's level is its
+// position plus one.
+var CanonicalRatings = []string{RatingStrongSell, RatingSell, RatingHold, RatingBuy, RatingStrongBuy}
+
+// ratingVocabulary maps the ratings brokerages publish, folded by
+// foldRating, to the canonical scale.
+var ratingVocabulary = map[string]string{
+	"strong buy":          RatingStrongBuy,
+	"conviction buy":      RatingStrongBuy,
+	"top pick":            RatingStrongBuy,
+	"strong outperform":   RatingStrongBuy,
+	"buy":                 RatingBuy,
+	"moderate buy":        RatingBuy,
+	"speculative buy":     RatingBuy,
+	"accumulate":          RatingBuy,
+	"add":                 RatingBuy,
+	"outperform":          RatingBuy,
+	"outperformer":        RatingBuy,
+	"market outperform":   RatingBuy,
+	"mkt outperform":      RatingBuy,
+	"sector outperform":   RatingBuy,
+	"overweight":          RatingBuy,
+	"positive":            RatingBuy,
+	"hold":                RatingHold,
+	"neutral":             RatingHold,
+	"sector neutral":      RatingHold,
+	"perform":             RatingHold,
+	"market perform":      RatingHold,
+	"mkt perform":         RatingHold,
+	"sector perform":      RatingHold,
+	"peer perform":        RatingHold,
+	"equal weight":        RatingHold,
+	"market weight":       RatingHold,
+	"sector weight":       RatingHold,
+	"in line":             RatingHold,
+	"inline":              RatingHold,
+	"fair value":          RatingHold,
+	"sell":                RatingSell,
+	"moderate sell":       RatingSell,
+	"reduce":              RatingSell,
+	"trim":                RatingSell,
+	"cautious":            RatingSell,
+	"negative":            RatingSell,
+	"underperform":        RatingSell,
+	"market underperform": RatingSell,
+	"sector underperform": RatingSell,
+	"underweight":         RatingSell,
+	"strong sell":         RatingStrongSell,
+}
+
+// foldRating lowercases a raw rating and turns dashes and underscores into
+// single spaces, so "Strong-Buy" and "strong buy" are the same rating.
+func foldRating(raw string) string {
+	raw = strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(raw))
+	return strings.Join(strings.Fields(raw), " ")
+}
+
+// NormalizeRating maps a raw provider rating onto the canonical scale. It
+// returns nil for empty and unknown ratings.
+func NormalizeRating(raw string) *string {
+	canonical, ok := ratingVocabulary[foldRating(raw)]
+	if !ok {
+		return nil
+	}
+	return &canonical
+}
+
+// RatingLevel is the 1 (strong sell) to 5 (strong buy) level of a
+// canonical rating, or 0 when it is not one.
+func RatingLevel(canonical string) int {
+	for i, rating := range CanonicalRatings {
+		if rating == canonical {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// RatingsFrom lists the canonical ratings at or above min.
+func RatingsFrom(min string) []string {
+	level := RatingLevel(min)
+	if level == 0 {
+		return nil
+	}
+	return CanonicalRatings[level-1:]
+}
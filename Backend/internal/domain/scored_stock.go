@@ -0,0 +1,24 @@
+package domain
+
+// ScoredStock is a stock ranked by a top-stocks strategy. Score is the
+// weighted mean of the factor values scaled to 0-100.
+type ScoredStock struct {
+	Stock
+	Strategy string        `json:"strategy"`
+	Score    float64       `json:"score"`
+	Factors  []FactorScore `json:"factors"`
+}
+
+// FactorScore is one factor's part of a score. Value is in [0, 1] and
+// Contribution is the points it added to Score.
+type FactorScore struct {
+	Name         string  `json:"name"`
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
+const (
+	DefaultTopLimit = 5
+	MaxTopLimit     = 50
+)
@@ -0,0 +1,53 @@
+package domain
+
+// Kinds of search suggestions.
+const (
+	SuggestionStock     = "stock"
+	SuggestionBrokerage = "brokerage"
+)
+
+// What a suggestion matched on, from the strongest match to the weakest.
+const (
+	MatchExactTicker  = "exact_ticker"
+	MatchTickerPrefix = "ticker_prefix"
+	MatchCompany      = "company"
+	MatchBrokerage    = "brokerage"
+)
+
+const (
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 25
+	MaxSearchLength    = 100
+	// SimilarityThreshold is the trigram similarity from which a company
+	// or brokerage counts as a fuzzy match, as pg_trgm's default.
+	SimilarityThreshold = 0.3
+)
+
+// Scores of the search tiers. A stock takes the first tier it matches:
+//
+//	exact ticker       100
+//	ticker prefix      50 + 10 * len(query) / len(ticker)
+//	company substring  20 + 10 * similarity
+//	company fuzzy      10 * similarity
+//
+// and a brokerage scores 15 + 10 * similarity on a substring match or
+// 10 * similarity on a fuzzy one. similarity is pg_trgm's trigram
+// similarity between the name and the query.
+const (
+	ScoreExactTicker     = 100
+	ScoreTickerPrefix    = 50
+	ScoreCompanyContains = 20
+	ScoreBrokerContains  = 15
+	ScoreSimilarity      = 10
+)
+
+// SearchSuggestion is one autocomplete entry. Stocks carry Ticker and
+// Company, brokerages only Brokerage. Higher scores rank first.
+type SearchSuggestion struct {
+	Kind      string  `json:"kind"`
+	Ticker    string  `json:"ticker,omitempty"`
+	Company   string  `json:"company,omitempty"`
+	Brokerage string  `json:"brokerage,omitempty"`
+	Match     string  `json:"match"`
+	Score     float64 `json:"score"`
+}
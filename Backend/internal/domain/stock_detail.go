@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// Company is a ticker of the company directory the sync maintains. Name is
+// the newest spelling seen.
+type Company struct {
+	Ticker string `json:"ticker"`
+	Name   string `json:"name"`
+	// FirstSeenAt and LastSeenAt are the times of its oldest and newest
+	// rating events.
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// StockDetail is the view of one ticker across every brokerage covering it.
+type StockDetail struct {
+	Company Company `json:"company"`
+	// Ratings holds the latest event of each brokerage, newest first.
+	Ratings   []Stock   `json:"ratings"`
+	Consensus Consensus `json:"consensus"`
+}
+
+// Consensus summarizes the latest rating of each brokerage on a ticker.
+type Consensus struct {
+	Brokerages int `json:"brokerages"`
+	// Rating is the canonical rating nearest to Score, the mean level of
+	// the known ratings. Both are nil when no rating is known.
+	Rating       *string        `json:"rating"`
+	Score        *float64       `json:"score"`
+	RatingCounts map[string]int `json:"rating_counts"`
+	// Targets is how many brokerages have a parsed target; the statistics
+	// below are over those targets and nil when there are none.
+	Targets      int      `json:"targets"`
+	TargetMean   *float64 `json:"target_mean"`
+	TargetMedian *float64 `json:"target_median"`
+	TargetHigh   *float64 `json:"target_high"`
+	TargetLow    *float64 `json:"target_low"`
+	// TargetStdDev is the population standard deviation and
+	// TargetDispersion the same in percent of the mean.
+	TargetStdDev     *float64 `json:"target_std_dev"`
+	TargetDispersion *float64 `json:"target_dispersion"`
+}
+
+// NewConsensus computes the consensus of the latest rating of each
+// brokerage.
+func NewConsensus(ratings []Stock) Consensus {
+	consensus := Consensus{
+		Brokerages:   len(ratings),
+		RatingCounts: map[string]int{},
+	}
+
+	levels, known := 0, 0
+	var targets []float64
+
+	for _, rating := range ratings {
+		if rating.RatingToCanonical != nil {
+			if level := RatingLevel(*rating.RatingToCanonical); level > 0 {
+				consensus.RatingCounts[*rating.RatingToCanonical]++
+				levels += level
+				known++
+			}
+		}
+		if rating.TargetTo != nil {
+			targets = append(targets, *rating.TargetTo)
+		}
+	}
+
+	if known > 0 {
+		score := float64(levels) / float64(known)
+		canonical := CanonicalRatings[int(math.Round(score))-1]
+		consensus.Score = &score
+		consensus.Rating = &canonical
+	}
+
+	consensus.Targets = len(targets)
+	if len(targets) == 0 {
+		return consensus
+	}
+
+	slices.Sort(targets)
+
+	sum := 0.0
+	for _, target := range targets {
+		sum += target
+	}
+	mean := sum / float64(len(targets))
+
+	variance := 0.0
+	for _, target := range targets {
+		variance += (target - mean) * (target - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(targets)))
+
+	median := targets[len(targets)/2]
+	if len(targets)%2 == 0 {
+		median = (targets[len(targets)/2-1] + median) / 2
+	}
+
+	consensus.TargetMean = &mean
+	consensus.TargetMedian = &median
+	consensus.TargetLow = &targets[0]
+	consensus.TargetHigh = &targets[len(targets)-1]
+	consensus.TargetStdDev = &stdDev
+
+	if mean != 0 {
+		dispersion := stdDev / mean * 100
+		consensus.TargetDispersion = &dispersion
+	}
+
+	return consensus
+}
+
+// CompaniesOf collects the companies of a batch of events, one per ticker,
+// with the spelling of the newest event.
+func CompaniesOf(stocks []Stock) []Company {
+	var companies []Company
+	index := map[string]int{}
+
+	for _, s := range stocks {
+		i, ok := index[s.Ticker]
+		if !ok {
+			index[s.Ticker] = len(companies)
+			companies = append(companies, Company{Ticker: s.Ticker, Name: s.Company, FirstSeenAt: s.Time, LastSeenAt: s.Time})
+			continue
+		}
+
+		c := &companies[i]
+		if s.Time.Before(c.FirstSeenAt) {
+			c.FirstSeenAt = s.Time
+		}
+		if !s.Time.Before(c.LastSeenAt) {
+			c.LastSeenAt = s.Time
+			c.Name = s.Company
+		}
+	}
+
+	return companies
+}
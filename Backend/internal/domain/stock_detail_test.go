@@ -0,0 +1,92 @@
+package domain_test
+
+import (
+	"backend/internal/domain"
+	"math"
+	"testing"
+)
+
+func rating(canonical string, target float64) domain.Stock {
+	stock := domain.Stock{RatingToCanonical: &canonical}
+	if target != 0 {
+		stock.TargetTo = &target
+	}
+	return stock
+}
+
+func near(got *float64, want float64) bool {
+	return got != nil && math.Abs(*got-want) < 1e-9
+}
+
+func TestNewConsensus(t *testing.T) {
+	consensus := domain.NewConsensus([]domain.Stock{
+		rating(domain.RatingBuy, 10),
+		rating(domain.RatingBuy, 40),
+		rating(domain.RatingHold, 20),
+		rating(domain.RatingStrongBuy, 30),
+		rating("unknown", 0),
+	})
+
+	if consensus.Brokerages != 5 {
+		t.Errorf("Brokerages = %d, want 5", consensus.Brokerages)
+	}
+	if !near(consensus.Score, 4) || consensus.Rating == nil || *consensus.Rating != domain.RatingBuy {
+		t.Errorf("Score = %v, Rating = %v; want 4 and %s", consensus.Score, consensus.Rating, domain.RatingBuy)
+	}
+	if consensus.RatingCounts[domain.RatingBuy] != 2 || consensus.RatingCounts[domain.RatingHold] != 1 || len(consensus.RatingCounts) != 3 {
+		t.Errorf("RatingCounts = %v", consensus.RatingCounts)
+	}
+
+	if consensus.Targets != 4 {
+		t.Errorf("Targets = %d, want 4", consensus.Targets)
+	}
+
+	stdDev := math.Sqrt(125)
+	checks := []struct {
+		name string
+		got  *float64
+		want float64
+	}{
+		{"TargetMean", consensus.TargetMean, 25},
+		{"TargetMedian", consensus.TargetMedian, 25},
+		{"TargetLow", consensus.TargetLow, 10},
+		{"TargetHigh", consensus.TargetHigh, 40},
+		{"TargetStdDev", consensus.TargetStdDev, stdDev},
+		{"TargetDispersion", consensus.TargetDispersion, stdDev / 25 * 100},
+	}
+
+	for _, c := range checks {
+		if !near(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestNewConsensusOddMedian(t *testing.T) {
+	consensus := domain.NewConsensus([]domain.Stock{
+		rating(domain.RatingSell, 60),
+		rating(domain.RatingSell, 10),
+		rating(domain.RatingSell, 20),
+	})
+
+	if !near(consensus.TargetMedian, 20) {
+		t.Errorf("TargetMedian = %v, want 20", consensus.TargetMedian)
+	}
+	if !near(consensus.TargetMean, 30) {
+		t.Errorf("TargetMean = %v, want 30", consensus.TargetMean)
+	}
+}
+
+func TestNewConsensusEmpty(t *testing.T) {
+	consensus := domain.NewConsensus(nil)
+
+	if consensus.Brokerages != 0 || consensus.Targets != 0 {
+		t.Fatalf("consensus = %+v, want no brokerages and no targets", consensus)
+	}
+	if consensus.Score != nil || consensus.Rating != nil {
+		t.Errorf("Score = %v, Rating = %v; want nil", consensus.Score, consensus.Rating)
+	}
+	if consensus.TargetMean != nil || consensus.TargetMedian != nil || consensus.TargetStdDev != nil || consensus.TargetDispersion != nil {
+		t.Errorf("target statistics set without targets: %+v", consensus)
+	}
+}
@@ -2,14 +2,37 @@ package domain
 
 import "time"
 
+// Stock is one analyst rating event. In the API, target_from and target_to
+// are numbers, or null when the provider text does not parse; they used to
+// be that text as strings, which target_from_raw and target_to_raw now
+// carry.
 type Stock struct {
-	Ticker     string    `json:"ticker"`
-	TargetFrom string    `json:"target_from"`
-	TargetTo   string    `json:"target_to"`
-	Company    string    `json:"company"`
-	Action     string    `json:"action"`
-	Brokerage  string    `json:"brokerage"`
-	RatingFrom string    `json:"rating_from"`
-	RatingTo   string    `json:"rating_to"`
-	Time       time.Time `json:"time"`
+	Ticker        string   `json:"ticker"`
+	TargetFrom    *float64 `json:"target_from"`
+	TargetTo      *float64 `json:"target_to"`
+	TargetFromRaw string   `json:"target_from_raw"`
+	TargetToRaw   string   `json:"target_to_raw"`
+	TargetChange  *float64 `json:"target_change"`
+	Company       string   `json:"company"`
+	Action        string   `json:"action"`
+	Brokerage     string   `json:"brokerage"`
+	RatingFrom    string   `json:"rating_from"`
+	RatingTo      string   `json:"rating_to"`
+	// RatingFromCanonical and RatingToCanonical place the raw ratings on
+	// the canonical scale, see CanonicalRatings. They are nil for ratings
+	// outside the known vocabulary.
+	RatingFromCanonical *string   `json:"rating_from_canonical"`
+	RatingToCanonical   *string   `json:"rating_to_canonical"`
+	Time                time.Time `json:"time"`
+}
+
+// PercentChange returns how much the target moved, in percent of the
+// previous target. It is nil when either target is unknown or from is zero.
+func PercentChange(from, to *float64) *float64 {
+	if from == nil || to == nil || *from == 0 {
+		return nil
+	}
+
+	change := (*to - *from) / *from * 100
+	return &change
 }
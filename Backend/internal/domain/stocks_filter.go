@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Values accepted by the action query parameter and the provider action
+// each one stands for.
+var StockActions = map[string]string{
+	"upgrade":        "upgraded by",
+	"downgrade":      "downgraded by",
+	"initiated":      "initiated by",
+	"reiterated":     "reiterated by",
+	"target_raised":  "target raised by",
+	"target_lowered": "target lowered by",
+	"target_set":     "target set by",
+}
+
+const MaxFilterLength = 100
+
+// StocksFilter narrows a listing. Every set field must match; zero values
+// match everything. Filters apply to every rating event, and the listing
+// shows the latest matching event of each ticker.
+type StocksFilter struct {
+	// Target is up, down or equal, comparing the target prices.
+	Target string
+	// Ticker is a ticker prefix.
+	Ticker string
+	// Brokerage and RatingTo match whole values, ignoring case.
+	Brokerage string
+	RatingTo  string
+	// Rating matches the canonical rating and MinRating the canonical
+	// ratings at least as bullish. Unknown ratings match neither.
+	Rating    string
+	MinRating string
+	// Action is a key of StockActions.
+	Action string
+	// Company matches a substring of the company name, ignoring case.
+	Company string
+	// From and To bound the event time, both inclusive.
+	From *time.Time
+	To   *time.Time
+	// MinTarget and MaxTarget bound the new target price.
+	MinTarget *float64
+	MaxTarget *float64
+	// MinChange and MaxChange bound the target change in percent. Stocks
+	// without one never match these.
+	MinChange *float64
+	MaxChange *float64
+}
+
+func (f StocksFilter) IsZero() bool {
+	return f.String() == ""
+}
+
+// String is a canonical encoding of the filter: equal filters give equal
+// strings.
+func (f StocksFilter) String() string {
+	values := url.Values{}
+
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	setTime := func(key string, t *time.Time) {
+		if t != nil {
+			values.Set(key, t.UTC().Format(time.RFC3339Nano))
+		}
+	}
+	setFloat := func(key string, v *float64) {
+		if v != nil {
+			values.Set(key, strconv.FormatFloat(*v, 'g', -1, 64))
+		}
+	}
+
+	set("filter", f.Target)
+	set("ticker", f.Ticker)
+	set("brokerage", f.Brokerage)
+	set("rating_to", f.RatingTo)
+	set("rating", f.Rating)
+	set("min_rating", f.MinRating)
+	set("action", f.Action)
+	set("company", f.Company)
+	setTime("from", f.From)
+	setTime("to", f.To)
+	setFloat("min_target", f.MinTarget)
+	setFloat("max_target", f.MaxTarget)
+	setFloat("min_change", f.MinChange)
+	setFloat("max_change", f.MaxChange)
+
+	return values.Encode()
+}
@@ -1,6 +1,57 @@
 package domain
 
+import "slices"
+
 type StocksPage struct {
 	Items    []Stock `json:"items"`
 	NextPage string  `json:"next_page"`
+	PrevPage string  `json:"prev_page,omitempty"`
+	// Next and Prev are where the neighbouring pages of a listing start,
+	// nil when there is none. Repositories set them and the service
+	// encodes them into NextPage and PrevPage.
+	Next *Cursor `json:"-"`
+	Prev *Cursor `json:"-"`
+}
+
+// NewStocksPage builds a listing page from rows read in the direction of
+// page, up to limit+1 of them; the extra row only says whether the listing
+// goes on that way. keys holds the sort key of every row.
+func NewStocksPage(rows []Stock, keys []string, page *Cursor, limit int, sort StocksSort) *StocksPage {
+	more := len(rows) > limit
+	if more {
+		rows, keys = rows[:limit], keys[:limit]
+	}
+
+	backward := page != nil && page.Backward
+	if backward {
+		rows, keys = slices.Clone(rows), slices.Clone(keys)
+		slices.Reverse(rows)
+		slices.Reverse(keys)
+	}
+
+	stocksPage := &StocksPage{Items: rows}
+
+	if len(rows) == 0 {
+		return stocksPage
+	}
+
+	first := &Cursor{Sort: sort, Key: keys[0], Ticker: rows[0].Ticker, Backward: true}
+	last := &Cursor{Sort: sort, Key: keys[len(keys)-1], Ticker: rows[len(rows)-1].Ticker}
+
+	if backward {
+		// Going back from a page means that page still follows.
+		stocksPage.Next = last
+		if more {
+			stocksPage.Prev = first
+		}
+	} else {
+		if more {
+			stocksPage.Next = last
+		}
+		if page != nil {
+			stocksPage.Prev = first
+		}
+	}
+
+	return stocksPage
 }
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+)
+
+// Values accepted by the filter query parameter. "all" is the same as
+// leaving it out.
+const (
+	StockFilterAll   = "all"
+	StockFilterUp    = "up"
+	StockFilterDown  = "down"
+	StockFilterEqual = "equal"
+)
+
+var StockFilters = []string{StockFilterAll, StockFilterUp, StockFilterDown, StockFilterEqual}
+
+const (
+	MaxTickerLength = 10
+	MaxCursorLength = 512
+)
+
+// TickerPattern matches upper-case tickers such as "AAPL", "BRK.B" or
+// "BF-B".
+var TickerPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9.\-]*$`)
+
+// StocksQueryParams describes what /api/v1/stocks accepts, so clients do
+// not have to hardcode it.
+type StocksQueryParams struct {
+	Filters         []string `json:"filters"`
+	TickerPattern   string   `json:"ticker_pattern"`
+	MaxTickerLength int      `json:"max_ticker_length"`
+	MaxCursorLength int      `json:"max_cursor_length"`
+	SortFields      []string `json:"sort_fields"`
+	Orders          []string `json:"orders"`
+	DefaultLimit    int      `json:"default_limit"`
+	MaxLimit        int      `json:"max_limit"`
+	Actions         []string `json:"actions"`
+	Ratings         []string `json:"ratings"`
+	MaxFilterLength int      `json:"max_filter_length"`
+}
+
+func NewStocksQueryParams() StocksQueryParams {
+	return StocksQueryParams{
+		Filters:         StockFilters,
+		TickerPattern:   TickerPattern.String(),
+		MaxTickerLength: MaxTickerLength,
+		MaxCursorLength: MaxCursorLength,
+		SortFields:      StockSortFields,
+		Orders:          SortOrders,
+		DefaultLimit:    DefaultPageSize,
+		MaxLimit:        MaxPageSize,
+		Actions:         ActionNames(),
+		Ratings:         CanonicalRatings,
+		MaxFilterLength: MaxFilterLength,
+	}
+}
+
+// ActionNames lists the keys of StockActions in order.
+func ActionNames() []string {
+	names := make([]string, 0, len(StockActions))
+	for name := range StockActions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
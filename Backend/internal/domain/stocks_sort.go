@@ -0,0 +1,50 @@
+package domain
+
+// Fields stock listings can be sorted by. Every sort breaks ties by
+// ticker in the same direction, so the order is total.
+const (
+	SortTicker       = "ticker"
+	SortTime         = "time"
+	SortCompany      = "company"
+	SortBrokerage    = "brokerage"
+	SortTargetChange = "target_change"
+)
+
+var StockSortFields = []string{SortTicker, SortTime, SortCompany, SortBrokerage, SortTargetChange}
+
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+var SortOrders = []string{OrderAsc, OrderDesc}
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+type StocksSort struct {
+	Field string
+	Desc  bool
+}
+
+var DefaultStocksSort = StocksSort{Field: SortTicker}
+
+func (s StocksSort) Order() string {
+	if s.Desc {
+		return OrderDesc
+	}
+	return OrderAsc
+}
+
+// Cursor is a position in a listing: the sort key of a row, as the
+// repository formats it, and its ticker. A forward cursor continues after
+// that row, a backward one returns the page before it. Stocks without a
+// target change sort last in both directions.
+type Cursor struct {
+	Sort     StocksSort
+	Key      string
+	Ticker   string
+	Backward bool
+}
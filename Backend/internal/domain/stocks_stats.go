@@ -7,3 +7,25 @@ type StocksStats struct {
 	NoChange   int `json:"no_change"`
 	Pages      int `json:"pages"`
 }
+
+// SetPages sets Pages to the number of pages of limit stocks in the
+// target bucket, or 0 when limit is not positive.
+func (s *StocksStats) SetPages(limit int, target string) {
+	if limit <= 0 {
+		s.Pages = 0
+		return
+	}
+
+	count := s.AllStocks
+
+	switch target {
+	case StockFilterUp:
+		count = s.UpStocks
+	case StockFilterDown:
+		count = s.DownStocks
+	case StockFilterEqual:
+		count = s.NoChange
+	}
+
+	s.Pages = (count + limit - 1) / limit
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// SyncResult is what a single sync run fetched and stored.
+type SyncResult struct {
+	PagesFetched   int `json:"pages_fetched"`
+	StocksUpserted int `json:"stocks_upserted"`
+	// LimiterWaitMs is the time spent waiting for the provider rate limiter.
+	LimiterWaitMs int64 `json:"limiter_wait_ms"`
+	// UnknownRatings are the raw ratings with no canonical rating, which
+	// the rating vocabulary should learn about.
+	UnknownRatings []string `json:"unknown_ratings"`
+}
+
+type SyncRun struct {
+	ID             int64      `json:"id"`
+	StartedAt      time.Time  `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at"`
+	PagesFetched   int        `json:"pages_fetched"`
+	StocksUpserted int        `json:"stocks_upserted"`
+	LimiterWaitMs  int64      `json:"limiter_wait_ms"`
+	UnknownRatings []string   `json:"unknown_ratings"`
+	Error          *string    `json:"error"`
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+const (
+	SyncStateIdle      = "idle"
+	SyncStateRunning   = "running"
+	SyncStateSucceeded = "succeeded"
+	SyncStateFailed    = "failed"
+)
+
+type SyncStatus struct {
+	State           string     `json:"state"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	PagesFetched    int        `json:"pages_fetched"`
+	StocksUpserted  int        `json:"stocks_upserted"`
+	LimiterWaitMs   int64      `json:"limiter_wait_ms"`
+	DurationSeconds float64    `json:"duration_seconds"`
+	UnknownRatings  []string   `json:"unknown_ratings,omitempty"`
+	LastError       *string    `json:"last_error,omitempty"`
+}
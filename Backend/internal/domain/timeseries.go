@@ -0,0 +1,112 @@
+package domain
+
+import "time"
+
+// Values accepted by the bucket query parameter of the time series.
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+)
+
+var TimeseriesBuckets = []string{BucketDay, BucketWeek, BucketMonth}
+
+// Values accepted by the group_by query parameter of the time series.
+const (
+	GroupByBrokerage = "brokerage"
+	GroupByTicker    = "ticker"
+)
+
+var TimeseriesGroups = []string{GroupByBrokerage, GroupByTicker}
+
+// MaxTimeseriesBuckets bounds how many buckets a from/to range may span. A
+// missing To is the end of the current bucket and a missing From the start
+// of the widest range ending at To.
+const MaxTimeseriesBuckets = 1000
+
+// TimeseriesQuery selects the events counted by a time series.
+type TimeseriesQuery struct {
+	Bucket string
+	// GroupBy splits the series by brokerage slug or ticker; empty gives a
+	// single series.
+	GroupBy string
+	// From and To bound the event time, both inclusive.
+	From *time.Time
+	To   *time.Time
+}
+
+// TimeseriesPoint counts the rating events of one bucket. Bucket is the
+// UTC start of the bucket; weeks start on Monday.
+type TimeseriesPoint struct {
+	Key         string    `json:"-"`
+	Bucket      time.Time `json:"bucket"`
+	Actions     int       `json:"actions"`
+	Upgrades    int       `json:"upgrades"`
+	Downgrades  int       `json:"downgrades"`
+	Initiations int       `json:"initiations"`
+	// AvgTargetChange is the mean target change in percent of the events
+	// that have one.
+	AvgTargetChange *float64 `json:"avg_target_change"`
+}
+
+// TimeseriesSeries is the points of one brokerage or ticker, or of every
+// event when the series is not grouped. Buckets without events are left
+// out.
+type TimeseriesSeries struct {
+	Key    string            `json:"key,omitempty"`
+	Points []TimeseriesPoint `json:"points"`
+}
+
+type Timeseries struct {
+	Bucket  string             `json:"bucket"`
+	GroupBy string             `json:"group_by,omitempty"`
+	Series  []TimeseriesSeries `json:"series"`
+}
+
+// TruncateTime returns the UTC start of the bucket t falls in.
+func TruncateTime(t time.Time, bucket string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch bucket {
+	case BucketWeek:
+		// Weekday counts from Sunday; weeks start on Monday.
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case BucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// AddBuckets returns the UTC start of the bucket n buckets after the one t
+// falls in; n may be negative.
+func AddBuckets(t time.Time, bucket string, n int) time.Time {
+	start := TruncateTime(t, bucket)
+
+	switch bucket {
+	case BucketWeek:
+		return start.AddDate(0, 0, 7*n)
+	case BucketMonth:
+		return start.AddDate(0, n, 0)
+	default:
+		return start.AddDate(0, 0, n)
+	}
+}
+
+// CountBuckets returns how many buckets the range from..to touches.
+func CountBuckets(from, to time.Time, bucket string) int {
+	start, end := TruncateTime(from, bucket), TruncateTime(to, bucket)
+	if end.Before(start) {
+		return 0
+	}
+
+	switch bucket {
+	case BucketWeek:
+		return int(end.Sub(start).Hours()/(24*7)) + 1
+	case BucketMonth:
+		return (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1
+	default:
+		return int(end.Sub(start).Hours()/24) + 1
+	}
+}
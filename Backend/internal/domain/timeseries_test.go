@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"backend/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestAddBuckets(t *testing.T) {
+	// A Wednesday.
+	at := time.Date(2025, time.March, 12, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		bucket string
+		n      int
+		want   time.Time
+	}{
+		{domain.BucketDay, 0, time.Date(2025, time.March, 12, 0, 0, 0, 0, time.UTC)},
+		{domain.BucketDay, -12, time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC)},
+		{domain.BucketWeek, 0, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)},
+		{domain.BucketWeek, 1, time.Date(2025, time.March, 17, 0, 0, 0, 0, time.UTC)},
+		{domain.BucketMonth, -3, time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := domain.AddBuckets(at, tt.bucket, tt.n); !got.Equal(tt.want) {
+			t.Errorf("AddBuckets(%s, %d) = %v, want %v", tt.bucket, tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestAddBucketsWidestRange checks the default from of an open-ended time
+// series: the widest range CountBuckets still accepts.
+func TestAddBucketsWidestRange(t *testing.T) {
+	to := time.Date(2025, time.March, 12, 15, 30, 0, 0, time.UTC)
+
+	for _, bucket := range domain.TimeseriesBuckets {
+		from := domain.AddBuckets(to, bucket, 1-domain.MaxTimeseriesBuckets)
+		if got := domain.CountBuckets(from, to, bucket); got != domain.MaxTimeseriesBuckets {
+			t.Errorf("%s: CountBuckets = %d, want %d", bucket, got, domain.MaxTimeseriesBuckets)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"backend/internal/apierror"
+	"backend/internal/domain"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// RequireToken only lets through requests carrying "Authorization: Bearer
+// <token>". With an empty token every request is rejected.
+func RequireToken(token string) func(http.Handler) http.Handler {
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header := r.Header.Get("Authorization")
+			provided, ok := strings.CutPrefix(header, "Bearer ")
+
+			if token == "" || !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				apierror.Write(w, r, domain.ErrUnauthorized, "Missing or invalid bearer token")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// Cache lets clients revalidate successful GET answers: it tags them with
+// an ETag of the data version and the request URL, and answers 304 without
+// calling the handler when If-None-Match already holds it. version must
+// change whenever the data behind the answers does. Clients must revalidate
+// every time; other answers pass through untagged.
+func Cache(version func() string) func(http.Handler) http.Handler {
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sum := sha256.Sum256([]byte(version() + "\x00" + r.URL.RequestURI()))
+			etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+			if etagMatches(r.Header.Get("If-None-Match"), etag) {
+				w.Header().Set("ETag", etag)
+				w.Header().Set("Cache-Control", "no-cache")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			next.ServeHTTP(&taggingWriter{ResponseWriter: w, etag: etag}, r)
+		})
+	}
+}
+
+// taggingWriter adds the ETag to the answer once its status is known, so
+// errors are not tagged.
+type taggingWriter struct {
+	http.ResponseWriter
+	etag        string
+	wroteHeader bool
+}
+
+func (t *taggingWriter) WriteHeader(status int) {
+	if t.wroteHeader {
+		return
+	}
+
+	t.wroteHeader = true
+
+	if status == http.StatusOK {
+		t.Header().Set("ETag", t.etag)
+		t.Header().Set("Cache-Control", "no-cache")
+	}
+
+	t.ResponseWriter.WriteHeader(status)
+}
+
+func (t *taggingWriter) Write(p []byte) (int, error) {
+	if !t.wroteHeader {
+		t.WriteHeader(http.StatusOK)
+	}
+	return t.ResponseWriter.Write(p)
+}
+
+// etagMatches applies the weak comparison If-None-Match calls for.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
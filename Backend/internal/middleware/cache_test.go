@@ -0,0 +1,84 @@
+package middleware_test
+
+import (
+	"backend/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	version := "1"
+	calls := 0
+
+	handler := middleware.Cache(func() string { return version })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("fail") != "" {
+			http.Error(w, "bad", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+
+	get := func(target, ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		if ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	first := get("/stocks?page=1", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Header().Get("Cache-Control") != "no-cache" {
+		t.Fatalf("first answer = %d, ETag %q, Cache-Control %q", first.Code, etag, first.Header().Get("Cache-Control"))
+	}
+
+	if w := get("/stocks?page=1", etag); w.Code != http.StatusNotModified || calls != 1 {
+		t.Fatalf("revalidation = %d after %d handler calls, want 304 after 1", w.Code, calls)
+	}
+
+	if w := get("/stocks?page=2", etag); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Fatalf("another query = %d with ETag %q, want 200 and a new tag", w.Code, w.Header().Get("ETag"))
+	}
+
+	version = "2"
+	if w := get("/stocks?page=1", etag); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Fatalf("after a new version = %d with ETag %q, want 200 and a new tag", w.Code, w.Header().Get("ETag"))
+	}
+
+	if w := get("/stocks?fail=1", ""); w.Code != http.StatusBadRequest || w.Header().Get("ETag") != "" {
+		t.Fatalf("error answer = %d with ETag %q, want 400 untagged", w.Code, w.Header().Get("ETag"))
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		id   string
+		kept bool
+	}{
+		{"abc-123_X.y", true},
+		{"", false},
+		{"has space", false},
+		{"line\r\nbreak", false},
+		{"ünïcode", false},
+		{strings.Repeat("a", 65), false},
+	}
+
+	handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header["X-Request-Id"] = []string{tt.id}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		got := w.Header().Get("X-Request-ID")
+		if got == "" || (got == tt.id) != tt.kept {
+			t.Errorf("id %q answered as %q, kept %t", tt.id, got, tt.kept)
+		}
+	}
+}
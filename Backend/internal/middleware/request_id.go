@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"backend/internal/requestid"
+	"net/http"
+)
+
+// RequestID reuses the caller's X-Request-ID when it is a plain token,
+// otherwise generates one, and exposes it in the response and the request
+// context.
+func RequestID(next http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		id := r.Header.Get(requestid.Header)
+		if !validRequestID(id) {
+			id = requestid.New()
+		}
+
+		w.Header().Set(requestid.Header, id)
+
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
+
+// validRequestID accepts up to 64 letters, digits, dots, dashes and
+// underscores, so a caller's id cannot forge log lines or headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+
+	for _, c := range []byte(id) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '.', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,72 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrEmpty = errors.New("money: empty amount")
+
+// Parse turns the amounts reported by the provider ("$1,234.56", " 12 ",
+// "USD 4.5", "4.5 USD", "(3.00)") into a number. Parenthesised amounts are
+// negative.
+func Parse(raw string) (float64, error) {
+	value := strings.TrimSpace(raw)
+
+	if value == "" {
+		return 0, ErrEmpty
+	}
+
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+
+	if strings.HasPrefix(value, "-") {
+		negative = !negative
+		value = strings.TrimSpace(value[1:])
+	}
+
+	value = strings.ToUpper(value)
+	value = strings.TrimPrefix(value, "USD")
+	value = strings.TrimSuffix(value, "USD")
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "$")
+	value = strings.ReplaceAll(value, ",", "")
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return 0, ErrEmpty
+	}
+
+	for _, r := range value {
+		if (r < '0' || r > '9') && r != '.' {
+			return 0, fmt.Errorf("money: invalid amount %q", raw)
+		}
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("money: invalid amount %q", raw)
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// ParsePtr is Parse for optional columns: amounts that cannot be parsed
+// become nil instead of an error.
+func ParsePtr(raw string) *float64 {
+	amount, err := Parse(raw)
+	if err != nil {
+		return nil
+	}
+
+	return &amount
+}
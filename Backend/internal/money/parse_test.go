@@ -0,0 +1,76 @@
+package money_test
+
+import (
+	"backend/internal/money"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want float64
+	}{
+		{"$1,234.56", 1234.56},
+		{" 12 ", 12},
+		{"$.5", 0.5},
+		{"-$4.20", -4.2},
+		{"- 4.20", -4.2},
+		{"(3.00)", -3},
+		{"($1,000)", -1000},
+		{"USD 4.5", 4.5},
+		{"usd4.5", 4.5},
+		{"4.5 USD", 4.5},
+		{"$1,234.56 USD", 1234.56},
+	}
+
+	for _, tt := range tests {
+		got, err := money.Parse(tt.raw)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.raw, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []struct {
+		raw   string
+		empty bool
+	}{
+		{"", true},
+		{"   ", true},
+		{"$", true},
+		{"USD", true},
+		{"()", true},
+		{"abc", false},
+		{"$12abc", false},
+		{"1.2.3", false},
+		{"$-5", false},
+		{"12 EUR", false},
+	}
+
+	for _, tt := range tests {
+		_, err := money.Parse(tt.raw)
+		if err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", tt.raw)
+			continue
+		}
+		if errors.Is(err, money.ErrEmpty) != tt.empty {
+			t.Errorf("Parse(%q) = %v, empty %t", tt.raw, err, tt.empty)
+		}
+	}
+}
+
+func TestParsePtr(t *testing.T) {
+	if got := money.ParsePtr("n/a"); got != nil {
+		t.Fatalf("ParsePtr(n/a) = %v, want nil", *got)
+	}
+
+	if got := money.ParsePtr("$7"); got == nil || *got != 7 {
+		t.Fatalf("ParsePtr($7) = %v, want 7", got)
+	}
+}
@@ -1,16 +1,42 @@
 package ports
 
-import "backend/internal/domain"
+import (
+	"backend/internal/domain"
+	"context"
+	"time"
+)
 
 type StockProvider interface {
-	FetchStocks(page *string) (*domain.StocksPage, error)
+	FetchStocks(ctx context.Context, page *string) (*domain.StocksPage, error)
+}
+
+// RateLimitedProvider is implemented by providers that throttle their
+// requests. LimiterWait is the total time spent waiting on the limiter.
+type RateLimitedProvider interface {
+	LimiterWait() time.Duration
 }
 
 type StocksRepository interface {
-	Upsert(stocks []domain.Stock) error
-	GetStocks(page *string, limit int) (*domain.StocksPage, error)
-	GetTopStocks(limit int) (*[]domain.Stock, error)
-	GetFilterStocks(page *string, limit int, filter *string) (*domain.StocksPage, error)
-	GetStats(limit int, filter *string, ticker *string) (*domain.StocksStats, error)
-	GetStockByTicker(ticker string, limit int, page *string, filter *string) (*domain.StocksPage, error)
+	Upsert(ctx context.Context, stocks []domain.Stock) error
+	GetStocks(ctx context.Context, page *domain.Cursor, limit int, sort domain.StocksSort) (*domain.StocksPage, error)
+	// GetLatestRatings returns the newest event of every ticker and
+	// brokerage, ordered by ticker and brokerage slug, for one ticker or all
+	// of them when ticker is empty. Spellings of a brokerage sharing a slug
+	// count as one brokerage.
+	GetLatestRatings(ctx context.Context, ticker string) (*[]domain.Stock, error)
+	// GetCompany returns nil when no company has ticker.
+	GetCompany(ctx context.Context, ticker string) (*domain.Company, error)
+	// GetTimeseries counts every rating event per bucket and key, ordered
+	// by key and bucket.
+	GetTimeseries(ctx context.Context, query domain.TimeseriesQuery) ([]domain.TimeseriesPoint, error)
+	GetFilterStocks(ctx context.Context, page *domain.Cursor, limit int, filter domain.StocksFilter, sort domain.StocksSort) (*domain.StocksPage, error)
+	GetStats(ctx context.Context, limit int, filter domain.StocksFilter) (*domain.StocksStats, error)
+	GetStockHistory(ctx context.Context, ticker string) (*[]domain.Stock, error)
+	Search(ctx context.Context, query string, limit int) ([]domain.SearchSuggestion, error)
+	GetBrokerages(ctx context.Context) ([]domain.BrokerageSummary, error)
+	// GetBrokerage returns nil when no brokerage has slug.
+	GetBrokerage(ctx context.Context, slug string) (*domain.Brokerage, error)
+	// GetBrokerageActions pages through the latest event of the brokerage
+	// on each ticker it covers.
+	GetBrokerageActions(ctx context.Context, slug string, page *domain.Cursor, limit int, filter domain.StocksFilter, sort domain.StocksSort) (*domain.StocksPage, error)
 }
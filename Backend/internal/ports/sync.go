@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"backend/internal/domain"
+	"context"
+	"time"
+)
+
+type SyncRunsRepository interface {
+	StartRun(ctx context.Context, startedAt time.Time) (int64, error)
+	FinishRun(ctx context.Context, run domain.SyncRun) error
+	GetLastRun(ctx context.Context) (*domain.SyncRun, error)
+}
+
+// SyncCheckpointsRepository stores the provider cursor an interrupted sync
+// can resume from.
+type SyncCheckpointsRepository interface {
+	GetCheckpoint(ctx context.Context) (*string, error)
+	SaveCheckpoint(ctx context.Context, cursor string) error
+	ClearCheckpoint(ctx context.Context) error
+}
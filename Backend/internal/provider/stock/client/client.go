@@ -1,20 +1,28 @@
 package client
 
 import (
+	"backend/internal/ratelimit"
 	"net/http"
 	"time"
 )
 
+// Client talks to the stock provider. Limiter is shared by every request
+// made through the client, retries included, so all callers draw from the
+// same budget.
 type Client struct {
 	ApiURL       string
 	Autorization string
+	Retry        RetryPolicy
+	Limiter      *ratelimit.Limiter
 	httpClient   *http.Client
 }
 
-func NewClient(apiURL, autorization string) *Client {
+func NewClient(apiURL, autorization string, retry RetryPolicy, limiter *ratelimit.Limiter) *Client {
 	return &Client{
 		ApiURL:       apiURL,
 		Autorization: autorization,
+		Retry:        retry,
+		Limiter:      limiter,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
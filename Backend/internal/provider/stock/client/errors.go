@@ -0,0 +1,47 @@
+package client
+
+import (
+	"backend/internal/domain"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrUnauthorized means the provider rejected the credentials (401/403).
+	// Retrying will not help.
+	ErrUnauthorized = errors.New("provider: unauthorized")
+	// ErrRateLimited means the provider answered 429 on every attempt.
+	ErrRateLimited error = &sentinel{"provider: rate limited", domain.ErrUpstreamUnavailable}
+	// ErrUpstream covers network failures, 5xx answers and unexpected or
+	// unreadable responses.
+	ErrUpstream error = &sentinel{"provider: upstream error", domain.ErrUpstreamUnavailable}
+)
+
+// sentinel is a provider error that also matches a domain error kind,
+// without that kind showing up in the message.
+type sentinel struct {
+	message string
+	kind    error
+}
+
+func (e *sentinel) Error() string { return e.message }
+
+func (e *sentinel) Unwrap() error { return e.kind }
+
+// StatusError is a non-200 answer from the provider. It unwraps to one of
+// the sentinel errors above.
+type StatusError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+	kind       error
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%v: status %d: %s", e.kind, e.StatusCode, e.Body)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.kind
+}
@@ -1,22 +1,61 @@
 package client
 
 import (
-	"backend/internal/domain"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"time"
 )
 
-func (c *Client) FetchStocks(page *string) (*domain.StocksPage, error) {
+// FetchStocks fetches one page, retrying transient failures according to
+// the client's RetryPolicy. Errors wrap ErrUnauthorized, ErrRateLimited or
+// ErrUpstream.
+func (c *Client) FetchStocks(ctx context.Context, page *string) (*StocksResponse, error) {
 	if c.ApiURL == "" {
 		return nil, errors.New("provider client: empty ApiURL (check PROVIDER_URL/API_ENDPOINT)")
 	}
 
-	req, err := http.NewRequest(http.MethodGet, c.ApiURL, nil)
+	for attempt := 0; ; attempt++ {
+		result, retryable, err := c.fetchStocks(ctx, page)
+
+		if err == nil {
+			return result, nil
+		}
+
+		if !retryable || attempt >= c.Retry.MaxRetries || ctx.Err() != nil {
+			return nil, err
+		}
+
+		delay := c.Retry.backoff(attempt)
+
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+			delay = c.Retry.clamp(statusErr.RetryAfter)
+		}
+
+		log.Printf("[PROVIDER] attempt %d failed, retrying in %s: %v", attempt+1, delay, err)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// fetchStocks performs a single request and reports whether its failure is
+// worth retrying.
+func (c *Client) fetchStocks(ctx context.Context, page *string) (*StocksResponse, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.ApiURL, nil)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	if page != nil {
@@ -27,25 +66,55 @@ func (c *Client) FetchStocks(page *string) (*domain.StocksPage, error) {
 
 	req.Header.Set("Authorization", c.Autorization)
 
+	if _, err := c.Limiter.Wait(ctx); err != nil {
+		return nil, false, err
+	}
+
 	resp, err := c.httpClient.Do(req)
 
 	if err != nil {
-		return nil, err
+		if ctx.Err() != nil {
+			return nil, false, ctx.Err()
+		}
+		return nil, true, fmt.Errorf("%w: %w", ErrUpstream, err)
 	}
 
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("provider: unexpected status %d: %s", resp.StatusCode, string(body))
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		statusErr := &StatusError{
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+			statusErr.kind = ErrUnauthorized
+			return nil, false, statusErr
+		case resp.StatusCode == http.StatusTooManyRequests:
+			statusErr.kind = ErrRateLimited
+			statusErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			return nil, true, statusErr
+		case resp.StatusCode >= 500:
+			statusErr.kind = ErrUpstream
+			statusErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			return nil, true, statusErr
+		default:
+			statusErr.kind = ErrUpstream
+			return nil, false, statusErr
+		}
 	}
 
-	var result domain.StocksPage
+	var result StocksResponse
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+		// A body cut off mid-stream is a transient failure; anything else
+		// is a malformed answer that will not fix itself.
+		retryable := errors.Is(err, io.ErrUnexpectedEOF)
+		return nil, retryable, fmt.Errorf("%w: decoding response: %w", ErrUpstream, err)
 	}
 
-	return &result, nil
-
+	return &result, false, nil
 }
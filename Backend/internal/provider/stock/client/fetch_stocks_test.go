@@ -0,0 +1,122 @@
+package client_test
+
+import (
+	"backend/internal/provider/stock/client"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// scripted answers each request with the next handler, and with a valid
+// page once they run out.
+func scripted(t *testing.T, handlers ...http.HandlerFunc) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1)) - 1
+
+		if n < len(handlers) {
+			handlers[n](w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items":[{"ticker":"AAA"}],"next_page":""}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func status(code int, retryAfter string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(code)
+	}
+}
+
+func newClient(url string, maxRetries int) *client.Client {
+	return client.NewClient(url, "", client.RetryPolicy{
+		MaxRetries: maxRetries,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   20 * time.Millisecond,
+	}, nil)
+}
+
+func TestFetchStocksRetriesServerErrors(t *testing.T) {
+	server, requests := scripted(t,
+		status(http.StatusInternalServerError, ""),
+		status(http.StatusBadGateway, ""),
+	)
+
+	result, err := newClient(server.URL, 3).FetchStocks(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("FetchStocks: %v", err)
+	}
+
+	if len(result.Items) != 1 || requests.Load() != 3 {
+		t.Fatalf("got %d items after %d requests, want 1 after 3", len(result.Items), requests.Load())
+	}
+}
+
+func TestFetchStocksCapsRetryAfter(t *testing.T) {
+	server, requests := scripted(t, status(http.StatusTooManyRequests, "3600"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+
+	if _, err := newClient(server.URL, 1).FetchStocks(ctx, nil); err != nil {
+		t.Fatalf("FetchStocks: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("waited %s, want Retry-After capped at MaxDelay", elapsed)
+	}
+
+	if requests.Load() != 2 {
+		t.Fatalf("made %d requests, want 2", requests.Load())
+	}
+}
+
+func TestFetchStocksGivesUp(t *testing.T) {
+	server, requests := scripted(t,
+		status(http.StatusTooManyRequests, "0"),
+		status(http.StatusTooManyRequests, "0"),
+		status(http.StatusTooManyRequests, "0"),
+	)
+
+	_, err := newClient(server.URL, 2).FetchStocks(context.Background(), nil)
+
+	if !errors.Is(err, client.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+
+	var statusErr *client.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("err = %v, want a 429 StatusError", err)
+	}
+
+	if requests.Load() != 3 {
+		t.Fatalf("made %d requests, want the first try and 2 retries", requests.Load())
+	}
+}
+
+func TestFetchStocksDoesNotRetryUnauthorized(t *testing.T) {
+	server, requests := scripted(t, status(http.StatusUnauthorized, ""))
+
+	_, err := newClient(server.URL, 3).FetchStocks(context.Background(), nil)
+
+	if !errors.Is(err, client.ErrUnauthorized) || requests.Load() != 1 {
+		t.Fatalf("err = %v after %d requests, want ErrUnauthorized after 1", err, requests.Load())
+	}
+}
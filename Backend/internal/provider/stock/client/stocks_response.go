@@ -0,0 +1,22 @@
+package client
+
+import "time"
+
+// StocksResponse is the page returned by the provider, with the price
+// targets still in their "$1,234.56" text form.
+type StocksResponse struct {
+	Items    []StockItem `json:"items"`
+	NextPage string      `json:"next_page"`
+}
+
+type StockItem struct {
+	Ticker     string    `json:"ticker"`
+	TargetFrom string    `json:"target_from"`
+	TargetTo   string    `json:"target_to"`
+	Company    string    `json:"company"`
+	Action     string    `json:"action"`
+	Brokerage  string    `json:"brokerage"`
+	RatingFrom string    `json:"rating_from"`
+	RatingTo   string    `json:"rating_to"`
+	Time       time.Time `json:"time"`
+}
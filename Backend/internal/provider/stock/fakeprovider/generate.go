@@ -0,0 +1,90 @@
+package fakeprovider
+
+import (
+	"backend/internal/provider/stock/client"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+var (
+	brokerages = []string{
+		"Goldman Sachs", "Morgan Stanley", "JPMorgan Chase & Co.", "Citigroup",
+		"Barclays", "Wells Fargo & Company", "Jefferies Financial Group", "UBS Group",
+	}
+	actions = []string{
+		"upgraded by", "downgraded by", "initiated by", "reiterated by",
+		"target raised by", "target lowered by",
+	}
+	ratings = []string{
+		"Strong-Buy", "Buy", "Outperform", "Overweight", "Neutral",
+		"Market Perform", "Hold", "Underweight", "Sell",
+	}
+)
+
+// generate builds the pages from opts.Seed so the same options always serve
+// the same data. Tickers are unique, derived from the item position.
+func generate(opts Options) [][]client.StockItem {
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	start := time.Date(2025, time.January, 2, 13, 30, 0, 0, time.UTC)
+
+	pages := make([][]client.StockItem, opts.Pages)
+	n := 0
+
+	for p := range pages {
+		items := make([]client.StockItem, opts.PageSize)
+
+		for i := range items {
+			ticker := tickerFor(n)
+			from := 5 + rng.Float64()*500
+			to := from * (0.7 + rng.Float64()*0.6)
+
+			items[i] = client.StockItem{
+				Ticker:     ticker,
+				TargetFrom: money(from),
+				TargetTo:   money(to),
+				Company:    ticker + " Holdings Inc.",
+				Action:     actions[rng.IntN(len(actions))],
+				Brokerage:  brokerages[rng.IntN(len(brokerages))],
+				RatingFrom: ratings[rng.IntN(len(ratings))],
+				RatingTo:   ratings[rng.IntN(len(ratings))],
+				Time:       start.Add(time.Duration(n) * 17 * time.Minute),
+			}
+			n++
+		}
+
+		pages[p] = items
+	}
+
+	return pages
+}
+
+// tickerFor spells n in base 26 with letters, padded to three letters.
+func tickerFor(n int) string {
+	letters := []byte{'A', 'A', 'A'}
+
+	for i := len(letters) - 1; i >= 0 && n > 0; i-- {
+		letters[i] = byte('A' + n%26)
+		n /= 26
+	}
+
+	for n > 0 {
+		letters = append([]byte{byte('A' + (n-1)%26)}, letters...)
+		n = (n - 1) / 26
+	}
+
+	return string(letters)
+}
+
+// money formats amounts the way the provider does, e.g. "$1,234.56".
+func money(amount float64) string {
+	cents := int64(amount*100 + 0.5)
+	whole, frac := cents/100, cents%100
+
+	digits := fmt.Sprintf("%d", whole)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+
+	return fmt.Sprintf("$%s.%02d", digits, frac)
+}
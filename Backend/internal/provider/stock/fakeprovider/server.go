@@ -0,0 +1,202 @@
+// Package fakeprovider serves provider-shaped stock pages for offline
+// development and tests. It can be mounted in an httptest.Server or run
+// through cmd/fakeprovider, and can inject latency and failures.
+package fakeprovider
+
+import (
+	"backend/internal/provider/stock/client"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Fault is a failure injected into a single response.
+type Fault int
+
+const (
+	FaultNone Fault = iota
+	FaultRateLimit
+	FaultServerError
+	FaultMalformed
+)
+
+type Options struct {
+	// Pages and PageSize shape the data set; Seed makes it reproducible.
+	Pages    int
+	PageSize int
+	Seed     uint64
+
+	// Latency is added to every response.
+	Latency time.Duration
+
+	// Probabilities, between 0 and 1, of answering 429, 500 or a body that
+	// is not valid JSON. They draw from a generator seeded with Seed.
+	RateLimitRate   float64
+	ServerErrorRate float64
+	MalformedRate   float64
+
+	// RetryAfter is sent with injected 429s when greater than zero.
+	RetryAfter time.Duration
+
+	// LoopAt makes page LoopAt (1-based) point back to the second page, so
+	// a client following next_page would never finish.
+	LoopAt int
+
+	// Token, when set, must be sent as "Authorization: Bearer <Token>".
+	Token string
+}
+
+// Server is an http.Handler impersonating the stock provider.
+type Server struct {
+	opts  Options
+	pages [][]client.StockItem
+
+	mu     sync.Mutex
+	rng    *rand.Rand
+	script []Fault
+
+	requests atomic.Int64
+}
+
+func New(opts Options) *Server {
+	if opts.Pages <= 0 {
+		opts.Pages = 1
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = 10
+	}
+
+	return &Server{
+		opts:  opts,
+		pages: generate(opts),
+		rng:   rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x5eed)),
+	}
+}
+
+// Script queues faults answered, in order, by the next requests before the
+// random rates apply again.
+func (s *Server) Script(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.script = append(s.script, faults...)
+}
+
+// Items returns every stock the server can serve, in page order.
+func (s *Server) Items() []client.StockItem {
+	var items []client.StockItem
+	for _, page := range s.pages {
+		items = append(items, page...)
+	}
+	return items
+}
+
+// Requests is the number of requests received so far.
+func (s *Server) Requests() int {
+	return int(s.requests.Load())
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+
+	if s.opts.Latency > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(s.opts.Latency):
+		}
+	}
+
+	if s.opts.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.opts.Token {
+		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	index, ok := pageIndex(r.URL.Query().Get("next_page"))
+	if !ok || index >= len(s.pages) {
+		http.Error(w, `{"error":"invalid next_page"}`, http.StatusBadRequest)
+		return
+	}
+
+	switch s.nextFault() {
+	case FaultRateLimit:
+		if s.opts.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(s.opts.RetryAfter.Seconds())))
+		}
+		http.Error(w, `{"error":"rate limited"}`, http.StatusTooManyRequests)
+		return
+	case FaultServerError:
+		http.Error(w, `{"error":"internal"}`, http.StatusInternalServerError)
+		return
+	case FaultMalformed:
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"ticker": AAPL}]}`))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(client.StocksResponse{
+		Items:    s.pages[index],
+		NextPage: s.nextCursor(index),
+	})
+}
+
+func (s *Server) nextFault() Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.script) > 0 {
+		fault := s.script[0]
+		s.script = s.script[1:]
+		return fault
+	}
+
+	roll := s.rng.Float64()
+
+	switch {
+	case roll < s.opts.RateLimitRate:
+		return FaultRateLimit
+	case roll < s.opts.RateLimitRate+s.opts.ServerErrorRate:
+		return FaultServerError
+	case roll < s.opts.RateLimitRate+s.opts.ServerErrorRate+s.opts.MalformedRate:
+		return FaultMalformed
+	default:
+		return FaultNone
+	}
+}
+
+func (s *Server) nextCursor(index int) string {
+	if s.opts.LoopAt > 0 && index == s.opts.LoopAt-1 && len(s.pages) > 1 {
+		return cursorFor(1)
+	}
+
+	if index+1 >= len(s.pages) {
+		return ""
+	}
+
+	return cursorFor(index + 1)
+}
+
+func cursorFor(index int) string {
+	return fmt.Sprintf("page-%d", index)
+}
+
+// pageIndex maps a next_page cursor to a page; no cursor is the first page.
+func pageIndex(cursor string) (int, bool) {
+	if cursor == "" {
+		return 0, true
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(cursor, "page-"))
+	if err != nil || n < 0 || !strings.HasPrefix(cursor, "page-") {
+		return 0, false
+	}
+
+	return n, true
+}
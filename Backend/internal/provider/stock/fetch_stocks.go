@@ -1,17 +1,36 @@
 package stock
 
-import "backend/internal/domain"
+import (
+	"backend/internal/domain"
+	"context"
+)
 
-func (p *Provider) FetchStocks(page *string) (*domain.StocksPage, error) {
+func (p *Provider) FetchStocks(ctx context.Context, page *string) (*domain.StocksPage, error) {
 
-	resp, err := p.Client.FetchStocks(page)
+	resp, err := p.Client.FetchStocks(ctx, page)
 
 	if err != nil {
 		return nil, err
 	}
 
+	items := make([]domain.Stock, 0, len(resp.Items))
+
+	for _, item := range resp.Items {
+		items = append(items, domain.Stock{
+			Ticker:        item.Ticker,
+			TargetFromRaw: item.TargetFrom,
+			TargetToRaw:   item.TargetTo,
+			Company:       item.Company,
+			Action:        item.Action,
+			Brokerage:     item.Brokerage,
+			RatingFrom:    item.RatingFrom,
+			RatingTo:      item.RatingTo,
+			Time:          item.Time,
+		})
+	}
+
 	return &domain.StocksPage{
-		Items:    resp.Items,
+		Items:    items,
 		NextPage: resp.NextPage,
 	}, nil
 }
@@ -1,6 +1,9 @@
 package stock
 
-import "backend/internal/provider/stock/client"
+import (
+	"backend/internal/provider/stock/client"
+	"time"
+)
 
 type Provider struct {
 	Client *client.Client
@@ -11,3 +14,8 @@ func NewProvider(client *client.Client) *Provider {
 		Client: client,
 	}
 }
+
+// LimiterWait is the total time requests have waited for the rate limiter.
+func (p *Provider) LimiterWait() time.Duration {
+	return p.Client.Limiter.TotalWait()
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Limiter is a token bucket: it holds up to burst tokens and refills rate
+// tokens per second. Every call to Wait takes one token, sleeping until one
+// is available. A nil *Limiter never waits.
+type Limiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+
+	waited atomic.Int64
+}
+
+// New returns a limiter allowing rate requests per second with bursts of
+// up to burst requests. A rate <= 0 disables limiting and returns nil.
+func New(rate float64, burst int) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available and returns how long it waited.
+// If ctx ends first the token is given back and ctx's error returned.
+func (l *Limiter) Wait(ctx context.Context) (time.Duration, error) {
+	if l == nil {
+		return 0, ctx.Err()
+	}
+
+	start := time.Now()
+	delay := l.reserve(start)
+
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.release()
+		waited := time.Since(start)
+		l.waited.Add(int64(waited))
+		return waited, ctx.Err()
+	case <-timer.C:
+		l.waited.Add(int64(delay))
+		return delay, nil
+	}
+}
+
+// TotalWait is the time every caller has spent waiting so far.
+func (l *Limiter) TotalWait() time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	return time.Duration(l.waited.Load())
+}
+
+func (l *Limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+}
+
+// reserve takes a token, letting the bucket go negative, and returns how
+// long the caller must wait for that token to have been refilled. Callers
+// queue up in the order they reserved.
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+
+	l.tokens += elapsed * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
@@ -0,0 +1,105 @@
+package ratelimit_test
+
+import (
+	"backend/internal/ratelimit"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterAllowsBurst(t *testing.T) {
+	l := ratelimit.New(1, 3)
+
+	for i := range 3 {
+		waited, err := l.Wait(context.Background())
+		if err != nil {
+			t.Fatalf("Wait %d: %v", i, err)
+		}
+		if waited != 0 {
+			t.Fatalf("Wait %d waited %s inside the burst", i, waited)
+		}
+	}
+
+	if l.TotalWait() != 0 {
+		t.Fatalf("TotalWait = %s, want 0", l.TotalWait())
+	}
+}
+
+func TestLimiterRefills(t *testing.T) {
+	l := ratelimit.New(50, 1)
+
+	if _, err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	start := time.Now()
+
+	waited, err := l.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	// One token every 20ms.
+	if waited < 10*time.Millisecond || time.Since(start) < 10*time.Millisecond {
+		t.Fatalf("waited %s, want about 20ms for the refill", waited)
+	}
+
+	if l.TotalWait() != waited {
+		t.Fatalf("TotalWait = %s, want %s", l.TotalWait(), waited)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+
+	if waited, _ := l.Wait(context.Background()); waited != 0 {
+		t.Fatalf("waited %s after the bucket refilled", waited)
+	}
+}
+
+func TestLimiterWaitCancelled(t *testing.T) {
+	l := ratelimit.New(1, 1)
+
+	if _, err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	if _, err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait = %v, want the deadline error", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("Wait returned after %s, want it to stop with ctx", elapsed)
+	}
+
+	// The cancelled wait gave its token back, so the next caller waits for
+	// one token only, not two.
+	ctx, cancel = context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+
+	if _, err := l.Wait(ctx); err != nil {
+		t.Fatalf("Wait after cancellation: %v", err)
+	}
+}
+
+func TestNilLimiter(t *testing.T) {
+	l := ratelimit.New(0, 5)
+	if l != nil {
+		t.Fatal("New(0, 5) returned a limiter, want nil")
+	}
+
+	if waited, err := l.Wait(context.Background()); waited != 0 || err != nil {
+		t.Fatalf("nil Wait = %s, %v", waited, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("nil Wait on a cancelled ctx = %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetBrokerage(ctx context.Context, slug string) (*domain.Brokerage, error) {
+	return load(ctx, r, key("GetBrokerage", slug), func() (*domain.Brokerage, error) {
+		return r.Repository.GetBrokerage(ctx, slug)
+	})
+}
@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetBrokerageActions(ctx context.Context, slug string, page *domain.Cursor, limit int, filter domain.StocksFilter, sort domain.StocksSort) (*domain.StocksPage, error) {
+	return loadPage(ctx, r, key("GetBrokerageActions", slug, page, limit, filter, sort), func() (*domain.StocksPage, error) {
+		return r.Repository.GetBrokerageActions(ctx, slug, page, limit, filter, sort)
+	})
+}
@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetBrokerages(ctx context.Context) ([]domain.BrokerageSummary, error) {
+	return load(ctx, r, key("GetBrokerages"), func() ([]domain.BrokerageSummary, error) {
+		return r.Repository.GetBrokerages(ctx)
+	})
+}
@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetCompany(ctx context.Context, ticker string) (*domain.Company, error) {
+	return load(ctx, r, key("GetCompany", ticker), func() (*domain.Company, error) {
+		return r.Repository.GetCompany(ctx, ticker)
+	})
+}
@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetFilterStocks(ctx context.Context, page *domain.Cursor, limit int, filter domain.StocksFilter, sort domain.StocksSort) (*domain.StocksPage, error) {
+	return loadPage(ctx, r, key("GetFilterStocks", page, limit, filter, sort), func() (*domain.StocksPage, error) {
+		return r.Repository.GetFilterStocks(ctx, page, limit, filter, sort)
+	})
+}
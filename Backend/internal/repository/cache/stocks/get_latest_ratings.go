@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetLatestRatings(ctx context.Context, ticker string) (*[]domain.Stock, error) {
+	return load(ctx, r, key("GetLatestRatings", ticker), func() (*[]domain.Stock, error) {
+		return r.Repository.GetLatestRatings(ctx, ticker)
+	})
+}
@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetStats(ctx context.Context, limit int, filter domain.StocksFilter) (*domain.StocksStats, error) {
+	return load(ctx, r, key("GetStats", limit, filter), func() (*domain.StocksStats, error) {
+		return r.Repository.GetStats(ctx, limit, filter)
+	})
+}
@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetStockHistory(ctx context.Context, ticker string) (*[]domain.Stock, error) {
+	return load(ctx, r, key("GetStockHistory", ticker), func() (*[]domain.Stock, error) {
+		return r.Repository.GetStockHistory(ctx, ticker)
+	})
+}
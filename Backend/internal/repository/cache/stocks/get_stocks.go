@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetStocks(ctx context.Context, page *domain.Cursor, limit int, sort domain.StocksSort) (*domain.StocksPage, error) {
+	return loadPage(ctx, r, key("GetStocks", page, limit, sort), func() (*domain.StocksPage, error) {
+		return r.Repository.GetStocks(ctx, page, limit, sort)
+	})
+}
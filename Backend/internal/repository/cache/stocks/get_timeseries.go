@@ -0,0 +1,41 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+// pointEntry is how a TimeseriesPoint is cached: its key is not part of its
+// JSON form.
+type pointEntry struct {
+	Key   string                 `json:"key"`
+	Point domain.TimeseriesPoint `json:"point"`
+}
+
+func (r *Repository) GetTimeseries(ctx context.Context, query domain.TimeseriesQuery) ([]domain.TimeseriesPoint, error) {
+	entries, err := load(ctx, r, key("GetTimeseries", query), func() ([]pointEntry, error) {
+		points, err := r.Repository.GetTimeseries(ctx, query)
+		if err != nil || points == nil {
+			return nil, err
+		}
+
+		entries := make([]pointEntry, len(points))
+		for i, point := range points {
+			entries[i] = pointEntry{Key: point.Key, Point: point}
+		}
+
+		return entries, nil
+	})
+
+	if err != nil || entries == nil {
+		return nil, err
+	}
+
+	points := make([]domain.TimeseriesPoint, len(entries))
+	for i, entry := range entries {
+		points[i] = entry.Point
+		points[i].Key = entry.Key
+	}
+
+	return points, nil
+}
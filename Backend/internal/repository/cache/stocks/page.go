@@ -0,0 +1,45 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+// pageEntry is how a StocksPage is cached: the page cursors are not part of
+// its JSON form.
+type pageEntry struct {
+	Items    []domain.Stock `json:"items"`
+	NextPage string         `json:"next_page"`
+	PrevPage string         `json:"prev_page"`
+	Next     *domain.Cursor `json:"next"`
+	Prev     *domain.Cursor `json:"prev"`
+}
+
+func loadPage(ctx context.Context, r *Repository, key string, fetch func() (*domain.StocksPage, error)) (*domain.StocksPage, error) {
+	entry, err := load(ctx, r, key, func() (*pageEntry, error) {
+		page, err := fetch()
+		if err != nil || page == nil {
+			return nil, err
+		}
+
+		return &pageEntry{
+			Items:    page.Items,
+			NextPage: page.NextPage,
+			PrevPage: page.PrevPage,
+			Next:     page.Next,
+			Prev:     page.Prev,
+		}, nil
+	})
+
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	return &domain.StocksPage{
+		Items:    entry.Items,
+		NextPage: entry.NextPage,
+		PrevPage: entry.PrevPage,
+		Next:     entry.Next,
+		Prev:     entry.Prev,
+	}, nil
+}
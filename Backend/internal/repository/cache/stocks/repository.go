@@ -0,0 +1,115 @@
+// Package stocks caches the reads of a ports.StocksRepository. The stored
+// stocks only change when the sync runs, so cached answers stay valid until
+// Invalidate is called once a run ends; upserts pass straight through.
+package stocks
+
+import (
+	"backend/internal/cache"
+	"backend/internal/ports"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+type Repository struct {
+	Repository ports.StocksRepository
+	Backend    cache.Backend
+	// TTL bounds how long an answer is served when no run invalidates it.
+	TTL time.Duration
+
+	// generation changes on every Invalidate, so an answer read before it
+	// is not stored after it. Invalidate holds mu while it clears, and
+	// stores check generation under mu, so no store lands in between.
+	mu         sync.RWMutex
+	generation uint64
+}
+
+func NewCacheRepository(repo ports.StocksRepository, backend cache.Backend, ttl time.Duration) *Repository {
+	return &Repository{
+		Repository: repo,
+		Backend:    backend,
+		TTL:        ttl,
+	}
+}
+
+// Invalidate drops every cached answer.
+func (r *Repository) Invalidate(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	return r.Backend.Clear(ctx)
+}
+
+func (r *Repository) currentGeneration() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.generation
+}
+
+// store saves value under key unless Invalidate ran since generation.
+func (r *Repository) store(ctx context.Context, key string, value []byte, generation uint64) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.generation != generation {
+		return nil
+	}
+
+	return r.Backend.Set(ctx, key, value, r.TTL)
+}
+
+// key identifies a call by its method and arguments.
+func key(method string, args ...any) string {
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		// Every argument is plain data, so this does not happen; an
+		// unshared key keeps the call uncached if it ever does.
+		return method + ":" + err.Error()
+	}
+
+	sum := sha256.Sum256(encoded)
+	return method + ":" + hex.EncodeToString(sum[:16])
+}
+
+// load serves key from the backend, or calls fetch and stores its answer.
+// Backend failures are logged and fall back to fetch; errors are not
+// cached.
+func load[T any](ctx context.Context, r *Repository, key string, fetch func() (T, error)) (T, error) {
+	cached, ok, err := r.Backend.Get(ctx, key)
+	if err != nil {
+		log.Printf("[CACHE] failed to read %s: %v", key, err)
+	}
+
+	if ok {
+		var value T
+		if err := json.Unmarshal(cached, &value); err == nil {
+			return value, nil
+		}
+		log.Printf("[CACHE] dropping unreadable %s: %v", key, err)
+	}
+
+	generation := r.currentGeneration()
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("[CACHE] failed to encode %s: %v", key, err)
+		return value, nil
+	}
+
+	if err := r.store(ctx, key, encoded, generation); err != nil {
+		log.Printf("[CACHE] failed to store %s: %v", key, err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,135 @@
+package stocks_test
+
+import (
+	"backend/internal/cache"
+	"backend/internal/domain"
+	"backend/internal/ports"
+	CacheRepository "backend/internal/repository/cache/stocks"
+	MemoryRepository "backend/internal/repository/memory/stocks"
+	"backend/internal/repository/repotest"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// invalidating drops the cache on every upsert, as a finished sync would,
+// so the suite sees its writes through the cache.
+type invalidating struct {
+	*CacheRepository.Repository
+}
+
+func (r invalidating) Upsert(ctx context.Context, stocks []domain.Stock) error {
+	if err := r.Repository.Upsert(ctx, stocks); err != nil {
+		return err
+	}
+
+	return r.Invalidate(ctx)
+}
+
+func TestRepository(t *testing.T) {
+	repotest.RunStocksRepository(t, func(t *testing.T) ports.StocksRepository {
+		return invalidating{CacheRepository.NewCacheRepository(MemoryRepository.NewRepository(), cache.NewLRU(100), time.Minute)}
+	})
+}
+
+func TestServesCachedUntilInvalidated(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+
+	repo := CacheRepository.NewCacheRepository(MemoryRepository.NewRepository(), cache.NewLRU(100), time.Minute)
+
+	if err := repo.Upsert(ctx, []domain.Stock{repotest.Stock("AAA", "Goldman Sachs", "upgraded by", "Buy", 10, 12, at)}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	count := func() int {
+		t.Helper()
+
+		page, err := repo.GetStocks(ctx, nil, 10, domain.DefaultStocksSort)
+		if err != nil {
+			t.Fatalf("GetStocks: %v", err)
+		}
+		return len(page.Items)
+	}
+
+	if got := count(); got != 1 {
+		t.Fatalf("listed %d stocks, want 1", got)
+	}
+
+	if err := repo.Upsert(ctx, []domain.Stock{repotest.Stock("BBB", "Barclays", "upgraded by", "Buy", 10, 12, at)}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	if got := count(); got != 1 {
+		t.Fatalf("listed %d stocks before Invalidate, want the cached 1", got)
+	}
+
+	if err := repo.Invalidate(ctx); err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+
+	if got := count(); got != 2 {
+		t.Fatalf("listed %d stocks after Invalidate, want 2", got)
+	}
+}
+
+// pausingBackend holds the first Set until release is closed.
+type pausingBackend struct {
+	*cache.LRU
+	setting chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (b *pausingBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	b.once.Do(func() {
+		close(b.setting)
+		<-b.release
+	})
+	return b.LRU.Set(ctx, key, value, ttl)
+}
+
+func TestInvalidateDuringStoreWins(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+
+	memory := MemoryRepository.NewRepository()
+	backend := &pausingBackend{LRU: cache.NewLRU(100), setting: make(chan struct{}), release: make(chan struct{})}
+	repo := CacheRepository.NewCacheRepository(memory, backend, time.Minute)
+
+	if err := memory.Upsert(ctx, []domain.Stock{repotest.Stock("AAA", "Goldman Sachs", "upgraded by", "Buy", 10, 12, at)}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	loaded := make(chan struct{})
+	go func() {
+		defer close(loaded)
+		repo.GetStocks(ctx, nil, 10, domain.DefaultStocksSort)
+	}()
+
+	// The read is storing the pre-sync page when the sync ends.
+	<-backend.setting
+	if err := memory.Upsert(ctx, []domain.Stock{repotest.Stock("BBB", "Barclays", "upgraded by", "Buy", 10, 12, at)}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	invalidated := make(chan struct{})
+	go func() {
+		defer close(invalidated)
+		repo.Invalidate(ctx)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(backend.release)
+	<-loaded
+	<-invalidated
+
+	page, err := repo.GetStocks(ctx, nil, 10, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetStocks: %v", err)
+	}
+	if len(page.Items) != 2 {
+		t.Fatalf("listed %d stocks after Invalidate, want 2 rather than the stale page", len(page.Items))
+	}
+}
@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) Search(ctx context.Context, query string, limit int) ([]domain.SearchSuggestion, error) {
+	return load(ctx, r, key("Search", query, limit), func() ([]domain.SearchSuggestion, error) {
+		return r.Repository.Search(ctx, query, limit)
+	})
+}
@@ -0,0 +1,12 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+// Upsert leaves the cache alone: a sync upserts many batches and the
+// cached answers are invalidated once, when it ends.
+func (r *Repository) Upsert(ctx context.Context, stocks []domain.Stock) error {
+	return r.Repository.Upsert(ctx, stocks)
+}
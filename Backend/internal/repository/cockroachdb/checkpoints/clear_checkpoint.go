@@ -0,0 +1,12 @@
+package checkpoints
+
+import (
+	"context"
+)
+
+func (r *Repository) ClearCheckpoint(ctx context.Context) error {
+
+	_, err := r.db.Exec(ctx, `DELETE FROM sync_checkpoints WHERE name = $1;`, stocksCheckpoint)
+
+	return err
+}
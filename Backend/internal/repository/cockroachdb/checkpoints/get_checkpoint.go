@@ -0,0 +1,30 @@
+package checkpoints
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetCheckpoint returns the saved cursor, or nil when there is none.
+func (r *Repository) GetCheckpoint(ctx context.Context) (*string, error) {
+
+	var cursor string
+
+	err := r.db.QueryRow(ctx, `
+	SELECT cursor
+	FROM sync_checkpoints
+	WHERE name = $1;
+	`, stocksCheckpoint).Scan(&cursor)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
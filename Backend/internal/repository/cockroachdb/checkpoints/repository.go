@@ -0,0 +1,18 @@
+package checkpoints
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// stocksCheckpoint names the row holding the stock sync cursor.
+const stocksCheckpoint = "stocks"
+
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
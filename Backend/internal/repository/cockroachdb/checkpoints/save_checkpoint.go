@@ -0,0 +1,15 @@
+package checkpoints
+
+import (
+	"context"
+)
+
+func (r *Repository) SaveCheckpoint(ctx context.Context, cursor string) error {
+
+	_, err := r.db.Exec(ctx, `
+	UPSERT INTO sync_checkpoints (name, cursor, updated_at)
+	VALUES ($1, $2, now());
+	`, stocksCheckpoint, cursor)
+
+	return err
+}
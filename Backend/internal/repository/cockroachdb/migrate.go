@@ -1,37 +1,267 @@
 package cockroachdb
 
 import (
+	"backend/internal/repository/cockroachdb/migrations"
 	"context"
 	"fmt"
+	"os"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const (
+	migrateTimeout   = 10 * time.Minute
+	lockPollInterval = 500 * time.Millisecond
+)
+
+type MigrationStatus struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+// Migrate applies every pending migration. Replicas starting at the same
+// time serialize on the schema_migrations_lock row, so each version runs once.
 func Migrate(db *pgxpool.Pool) error {
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
 
 	defer cancel()
 
-	_, err := db.Exec(ctx, `
-	CREATE TABLE IF NOT EXISTS stocks (
-		ticker TEXT PRIMARY KEY,
-		target_from TEXT,
-		target_to TEXT,
-		company TEXT,
-		action TEXT,
-		brokerage TEXT,
-		rating_from TEXT,
-		rating_to TEXT,
-		time TIMESTAMPTZ
-	);
-`)
+	release, err := lockMigrations(ctx, db)
 	if err != nil {
 		fmt.Printf("[MIGRATE][ERROR] Migrate Failed: %v\n", err)
 		return err
 	}
+	defer release()
+
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		fmt.Printf("[MIGRATE][ERROR] Migrate Failed: %v\n", err)
+		return err
+	}
+
+	for _, migration := range migrations.All {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err := runMigration(ctx, db, migration, true)
+		if err != nil {
+			fmt.Printf("[MIGRATE][ERROR] Migration %d_%s failed: %v\n", migration.Version, migration.Name, err)
+			return err
+		}
+
+		fmt.Printf("[MIGRATE] Applied %d_%s\n", migration.Version, migration.Name)
+	}
 
 	fmt.Printf("[MIGRATE] Migrate Successfully\n")
 	return nil
 }
+
+// MigrateDown reverts the last steps applied migrations, newest first.
+func MigrateDown(db *pgxpool.Pool, steps int) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+
+	defer cancel()
+
+	release, err := lockMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for i := len(migrations.All) - 1; i >= 0 && steps > 0; i-- {
+		migration := migrations.All[i]
+
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err := runMigration(ctx, db, migration, false)
+		if err != nil {
+			fmt.Printf("[MIGRATE][ERROR] Rollback %d_%s failed: %v\n", migration.Version, migration.Name, err)
+			return err
+		}
+
+		fmt.Printf("[MIGRATE] Reverted %d_%s\n", migration.Version, migration.Name)
+		steps--
+	}
+
+	return nil
+}
+
+// GetMigrationStatus lists every known migration with the time it was
+// applied, or a nil AppliedAt when it is still pending.
+func GetMigrationStatus(db *pgxpool.Pool) ([]MigrationStatus, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+
+	defer cancel()
+
+	if err := createMigrationTables(ctx, db); err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, 0, len(migrations.All))
+
+	for _, migration := range migrations.All {
+		state := MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+
+		if appliedAt, ok := applied[migration.Version]; ok {
+			state.AppliedAt = &appliedAt
+		}
+
+		status = append(status, state)
+	}
+
+	return status, nil
+}
+
+func createMigrationTables(ctx context.Context, db *pgxpool.Pool) error {
+	_, err := db.Exec(ctx, `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(ctx, `
+	CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+		id INT PRIMARY KEY,
+		locked_by TEXT,
+		locked_at TIMESTAMPTZ
+	);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(ctx, `INSERT INTO schema_migrations_lock (id) VALUES (1) ON CONFLICT (id) DO NOTHING;`)
+	return err
+}
+
+// lockMigrations waits until this process owns the migration lock. A lock
+// older than migrateTimeout belongs to a crashed process and is taken over.
+func lockMigrations(ctx context.Context, db *pgxpool.Pool) (func(), error) {
+
+	if err := createMigrationTables(ctx, db); err != nil {
+		return nil, err
+	}
+
+	hostname, _ := os.Hostname()
+	owner := fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixNano())
+
+	for {
+		tag, err := db.Exec(ctx, `
+		UPDATE schema_migrations_lock
+		SET locked_by = $1, locked_at = now()
+		WHERE id = 1
+			AND (locked_by IS NULL OR locked_at < now() - $2::INT * INTERVAL '1 second');
+		`, owner, int64(migrateTimeout/time.Second))
+		if err != nil {
+			return nil, err
+		}
+
+		if tag.RowsAffected() == 1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("migrate: waiting for lock: %w", ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+
+	release := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := db.Exec(ctx, `
+		UPDATE schema_migrations_lock
+		SET locked_by = NULL, locked_at = NULL
+		WHERE id = 1 AND locked_by = $1;
+		`, owner)
+		if err != nil {
+			fmt.Printf("[MIGRATE][ERROR] Releasing lock failed: %v\n", err)
+		}
+	}
+
+	return release, nil
+}
+
+func appliedMigrations(ctx context.Context, db *pgxpool.Pool) (map[int]time.Time, error) {
+
+	rows, err := db.Query(ctx, `SELECT version, applied_at FROM schema_migrations;`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+func runMigration(ctx context.Context, db *pgxpool.Pool, migration migrations.Migration, up bool) error {
+
+	statements := migration.Down
+	if up {
+		statements = migration.Up
+	}
+
+	return pgx.BeginFunc(ctx, db, func(tx pgx.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(ctx, statement); err != nil {
+				return err
+			}
+		}
+
+		if up && migration.Backfill != nil {
+			if err := migration.Backfill(ctx, tx); err != nil {
+				return err
+			}
+		}
+
+		if up {
+			_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2);`, migration.Version, migration.Name)
+			return err
+		}
+
+		_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1;`, migration.Version)
+		return err
+	})
+}
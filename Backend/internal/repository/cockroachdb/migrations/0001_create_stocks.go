@@ -0,0 +1,32 @@
+package migrations
+
+// Every rating event is its own row, identified by the natural key
+// (ticker, brokerage, time, action). The ALTER statements prepare tables
+// created when ticker alone was the primary key; 0015 changes their key.
+var createStocks = Migration{
+	Version: 1,
+	Name:    "create_stocks",
+	Up: []string{
+		`
+		CREATE TABLE IF NOT EXISTS stocks (
+			ticker TEXT NOT NULL,
+			target_from TEXT,
+			target_to TEXT,
+			company TEXT,
+			action TEXT NOT NULL,
+			brokerage TEXT NOT NULL,
+			rating_from TEXT,
+			rating_to TEXT,
+			time TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (ticker, brokerage, time, action)
+		);
+		`,
+		`ALTER TABLE stocks ALTER COLUMN action SET NOT NULL;`,
+		`ALTER TABLE stocks ALTER COLUMN brokerage SET NOT NULL;`,
+		`ALTER TABLE stocks ALTER COLUMN time SET NOT NULL;`,
+		`CREATE INDEX IF NOT EXISTS stocks_ticker_time_idx ON stocks (ticker, time DESC);`,
+	},
+	Down: []string{
+		`DROP TABLE IF EXISTS stocks;`,
+	},
+}
@@ -0,0 +1,27 @@
+package migrations
+
+// target_from/target_to keep the raw provider text while the *_value columns
+// hold the parsed amounts.
+var addNumericTargets = Migration{
+	Version: 2,
+	Name:    "add_numeric_targets",
+	Up: []string{
+		`ALTER TABLE stocks ADD COLUMN IF NOT EXISTS target_from_value DECIMAL(18, 4);`,
+		`ALTER TABLE stocks ADD COLUMN IF NOT EXISTS target_to_value DECIMAL(18, 4);`,
+		`
+		ALTER TABLE stocks ADD COLUMN IF NOT EXISTS target_change DECIMAL(18, 4) AS (
+			CASE
+				WHEN target_from_value <> 0
+				THEN (target_to_value - target_from_value) / target_from_value * 100
+			END
+		) STORED;
+		`,
+		`CREATE INDEX IF NOT EXISTS stocks_target_change_idx ON stocks (target_change DESC);`,
+	},
+	Down: []string{
+		`DROP INDEX IF EXISTS stocks@stocks_target_change_idx;`,
+		`ALTER TABLE stocks DROP COLUMN IF EXISTS target_change;`,
+		`ALTER TABLE stocks DROP COLUMN IF EXISTS target_to_value;`,
+		`ALTER TABLE stocks DROP COLUMN IF EXISTS target_from_value;`,
+	},
+}
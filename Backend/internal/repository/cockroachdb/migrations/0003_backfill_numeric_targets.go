@@ -0,0 +1,23 @@
+package migrations
+
+// Parses the targets of rows stored before the numeric columns existed. It
+// runs apart from 0002 because a column cannot be written in the same
+// transaction that adds it. 0016 parses the amounts this pattern misses.
+var backfillNumericTargets = Migration{
+	Version: 3,
+	Name:    "backfill_numeric_targets",
+	Up: []string{
+		`
+		UPDATE stocks
+		SET target_from_value = REPLACE(REPLACE(TRIM(target_from), '$', ''), ',', '')::DECIMAL
+		WHERE target_from_value IS NULL
+			AND target_from ~ '^\s*\$?[0-9,]*\.?[0-9]+\s*$';
+		`,
+		`
+		UPDATE stocks
+		SET target_to_value = REPLACE(REPLACE(TRIM(target_to), '$', ''), ',', '')::DECIMAL
+		WHERE target_to_value IS NULL
+			AND target_to ~ '^\s*\$?[0-9,]*\.?[0-9]+\s*$';
+		`,
+	},
+}
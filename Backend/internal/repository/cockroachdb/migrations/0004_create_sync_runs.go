@@ -0,0 +1,22 @@
+package migrations
+
+var createSyncRuns = Migration{
+	Version: 4,
+	Name:    "create_sync_runs",
+	Up: []string{
+		`
+		CREATE TABLE IF NOT EXISTS sync_runs (
+			id INT8 PRIMARY KEY DEFAULT unique_rowid(),
+			started_at TIMESTAMPTZ NOT NULL,
+			finished_at TIMESTAMPTZ,
+			pages_fetched INT NOT NULL DEFAULT 0,
+			stocks_upserted INT NOT NULL DEFAULT 0,
+			error TEXT,
+			INDEX sync_runs_started_at_idx (started_at DESC)
+		);
+		`,
+	},
+	Down: []string{
+		`DROP TABLE IF EXISTS sync_runs;`,
+	},
+}
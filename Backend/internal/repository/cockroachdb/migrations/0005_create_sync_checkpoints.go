@@ -0,0 +1,18 @@
+package migrations
+
+var createSyncCheckpoints = Migration{
+	Version: 5,
+	Name:    "create_sync_checkpoints",
+	Up: []string{
+		`
+		CREATE TABLE IF NOT EXISTS sync_checkpoints (
+			name TEXT PRIMARY KEY,
+			cursor TEXT NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		);
+		`,
+	},
+	Down: []string{
+		`DROP TABLE IF EXISTS sync_checkpoints;`,
+	},
+}
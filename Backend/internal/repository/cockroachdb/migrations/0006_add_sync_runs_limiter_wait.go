@@ -0,0 +1,12 @@
+package migrations
+
+var addSyncRunsLimiterWait = Migration{
+	Version: 6,
+	Name:    "add_sync_runs_limiter_wait",
+	Up: []string{
+		`ALTER TABLE sync_runs ADD COLUMN IF NOT EXISTS limiter_wait_ms INT8 NOT NULL DEFAULT 0;`,
+	},
+	Down: []string{
+		`ALTER TABLE sync_runs DROP COLUMN IF EXISTS limiter_wait_ms;`,
+	},
+}
@@ -0,0 +1,16 @@
+package migrations
+
+// Trigram indexes back the fuzzy company and brokerage matching of the
+// search endpoint; ticker prefixes already use the primary key.
+var addSearchIndexes = Migration{
+	Version: 7,
+	Name:    "add_search_indexes",
+	Up: []string{
+		`CREATE INDEX IF NOT EXISTS stocks_company_trgm_idx ON stocks USING GIN (company gin_trgm_ops);`,
+		`CREATE INDEX IF NOT EXISTS stocks_brokerage_trgm_idx ON stocks USING GIN (brokerage gin_trgm_ops);`,
+	},
+	Down: []string{
+		`DROP INDEX IF EXISTS stocks@stocks_brokerage_trgm_idx;`,
+		`DROP INDEX IF EXISTS stocks@stocks_company_trgm_idx;`,
+	},
+}
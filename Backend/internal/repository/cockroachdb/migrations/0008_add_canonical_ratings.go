@@ -0,0 +1,18 @@
+package migrations
+
+// rating_from/rating_to keep the raw provider text while the *_canonical
+// columns hold the rating on the canonical scale, NULL when unknown.
+var addCanonicalRatings = Migration{
+	Version: 8,
+	Name:    "add_canonical_ratings",
+	Up: []string{
+		`ALTER TABLE stocks ADD COLUMN IF NOT EXISTS rating_from_canonical STRING;`,
+		`ALTER TABLE stocks ADD COLUMN IF NOT EXISTS rating_to_canonical STRING;`,
+		`CREATE INDEX IF NOT EXISTS stocks_rating_to_canonical_idx ON stocks (rating_to_canonical);`,
+	},
+	Down: []string{
+		`DROP INDEX IF EXISTS stocks@stocks_rating_to_canonical_idx;`,
+		`ALTER TABLE stocks DROP COLUMN IF EXISTS rating_to_canonical;`,
+		`ALTER TABLE stocks DROP COLUMN IF EXISTS rating_from_canonical;`,
+	},
+}
@@ -0,0 +1,54 @@
+package migrations
+
+// ratingVocabulary is the rating vocabulary of domain.NormalizeRating as it
+// was when this migration was written. Later syncs keep the columns up to
+// date; this only covers rows stored before they existed.
+const ratingVocabulary = `(VALUES
+	('strong buy', 'strong_buy'), ('conviction buy', 'strong_buy'),
+	('top pick', 'strong_buy'), ('strong outperform', 'strong_buy'),
+	('buy', 'buy'), ('moderate buy', 'buy'), ('speculative buy', 'buy'),
+	('accumulate', 'buy'), ('add', 'buy'), ('outperform', 'buy'),
+	('outperformer', 'buy'), ('market outperform', 'buy'),
+	('mkt outperform', 'buy'), ('sector outperform', 'buy'),
+	('overweight', 'buy'), ('positive', 'buy'),
+	('hold', 'hold'), ('neutral', 'hold'), ('sector neutral', 'hold'),
+	('perform', 'hold'), ('market perform', 'hold'), ('mkt perform', 'hold'),
+	('sector perform', 'hold'), ('peer perform', 'hold'),
+	('equal weight', 'hold'), ('market weight', 'hold'),
+	('sector weight', 'hold'), ('in line', 'hold'), ('inline', 'hold'),
+	('fair value', 'hold'),
+	('sell', 'sell'), ('moderate sell', 'sell'), ('reduce', 'sell'),
+	('trim', 'sell'), ('cautious', 'sell'), ('negative', 'sell'),
+	('underperform', 'sell'), ('market underperform', 'sell'),
+	('sector underperform', 'sell'), ('underweight', 'sell'),
+	('strong sell', 'strong_sell')
+) AS vocabulary (raw, canonical)`
+
+// foldRating mirrors domain's folding: lower case, dashes and underscores
+// as spaces, runs of spaces collapsed.
+func foldRating(column string) string {
+	return `trim(regexp_replace(translate(lower(` + column + `), '-_', '  '), '\s+', ' ', 'g'))`
+}
+
+// Runs apart from 0008 because a column cannot be written in the same
+// transaction that adds it.
+var backfillCanonicalRatings = Migration{
+	Version: 9,
+	Name:    "backfill_canonical_ratings",
+	Up: []string{
+		`
+		UPDATE stocks
+		SET rating_from_canonical = vocabulary.canonical
+		FROM ` + ratingVocabulary + `
+		WHERE stocks.rating_from_canonical IS NULL
+			AND vocabulary.raw = ` + foldRating("stocks.rating_from") + `;
+		`,
+		`
+		UPDATE stocks
+		SET rating_to_canonical = vocabulary.canonical
+		FROM ` + ratingVocabulary + `
+		WHERE stocks.rating_to_canonical IS NULL
+			AND vocabulary.raw = ` + foldRating("stocks.rating_to") + `;
+		`,
+	},
+}
@@ -0,0 +1,12 @@
+package migrations
+
+var addSyncRunsUnknownRatings = Migration{
+	Version: 10,
+	Name:    "add_sync_runs_unknown_ratings",
+	Up: []string{
+		`ALTER TABLE sync_runs ADD COLUMN IF NOT EXISTS unknown_ratings STRING[] NOT NULL DEFAULT ARRAY[];`,
+	},
+	Down: []string{
+		`ALTER TABLE sync_runs DROP COLUMN IF EXISTS unknown_ratings;`,
+	},
+}
@@ -0,0 +1,25 @@
+package migrations
+
+// brokerages is the directory of brokerages kept by the sync. stocks rows
+// point at it through brokerage_slug.
+var createBrokerages = Migration{
+	Version: 11,
+	Name:    "create_brokerages",
+	Up: []string{
+		`
+		CREATE TABLE IF NOT EXISTS brokerages (
+			slug STRING PRIMARY KEY,
+			name STRING NOT NULL,
+			first_seen_at TIMESTAMPTZ NOT NULL,
+			last_seen_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`ALTER TABLE stocks ADD COLUMN IF NOT EXISTS brokerage_slug STRING;`,
+		`CREATE INDEX IF NOT EXISTS stocks_brokerage_slug_idx ON stocks (brokerage_slug, ticker, time DESC);`,
+	},
+	Down: []string{
+		`DROP INDEX IF EXISTS stocks@stocks_brokerage_slug_idx;`,
+		`ALTER TABLE stocks DROP COLUMN IF EXISTS brokerage_slug;`,
+		`DROP TABLE IF EXISTS brokerages;`,
+	},
+}
@@ -0,0 +1,31 @@
+package migrations
+
+// Fills brokerage_slug and the directory from the rows stored before they
+// existed. The slug expression mirrors domain.BrokerageSlug.
+var backfillBrokerages = Migration{
+	Version: 12,
+	Name:    "backfill_brokerages",
+	Up: []string{
+		`
+		UPDATE stocks
+		SET brokerage_slug = trim(BOTH '-' FROM regexp_replace(lower(brokerage), '[^a-z0-9]+', '-', 'g'))
+		WHERE brokerage_slug IS NULL;
+		`,
+		`
+		INSERT INTO brokerages (slug, name, first_seen_at, last_seen_at)
+		SELECT slug, name, first_seen_at, last_seen_at
+		FROM (
+			SELECT
+				brokerage_slug AS slug,
+				brokerage AS name,
+				min(time) OVER (PARTITION BY brokerage_slug) AS first_seen_at,
+				max(time) OVER (PARTITION BY brokerage_slug) AS last_seen_at,
+				row_number() OVER (PARTITION BY brokerage_slug ORDER BY time DESC) AS n
+			FROM stocks
+			WHERE brokerage_slug <> ''
+		)
+		WHERE n = 1
+		ON CONFLICT (slug) DO NOTHING;
+		`,
+	},
+}
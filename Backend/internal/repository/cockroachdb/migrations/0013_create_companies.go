@@ -0,0 +1,21 @@
+package migrations
+
+// companies is the directory of tickers kept by the sync, so the company
+// name no longer has to be read off a single stocks row.
+var createCompanies = Migration{
+	Version: 13,
+	Name:    "create_companies",
+	Up: []string{
+		`
+		CREATE TABLE IF NOT EXISTS companies (
+			ticker STRING PRIMARY KEY,
+			name STRING NOT NULL,
+			first_seen_at TIMESTAMPTZ NOT NULL,
+			last_seen_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+	},
+	Down: []string{
+		`DROP TABLE IF EXISTS companies;`,
+	},
+}
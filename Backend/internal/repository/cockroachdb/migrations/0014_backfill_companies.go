@@ -0,0 +1,24 @@
+package migrations
+
+// Fills the directory from the rows stored before it existed.
+var backfillCompanies = Migration{
+	Version: 14,
+	Name:    "backfill_companies",
+	Up: []string{
+		`
+		INSERT INTO companies (ticker, name, first_seen_at, last_seen_at)
+		SELECT ticker, name, first_seen_at, last_seen_at
+		FROM (
+			SELECT
+				ticker,
+				COALESCE(company, '') AS name,
+				min(time) OVER (PARTITION BY ticker) AS first_seen_at,
+				max(time) OVER (PARTITION BY ticker) AS last_seen_at,
+				row_number() OVER (PARTITION BY ticker ORDER BY time DESC) AS n
+			FROM stocks
+		)
+		WHERE n = 1
+		ON CONFLICT (ticker) DO NOTHING;
+		`,
+	},
+}
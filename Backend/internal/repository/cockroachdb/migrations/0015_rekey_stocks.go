@@ -0,0 +1,21 @@
+package migrations
+
+// Moves tables created when ticker alone was the primary key onto the
+// natural key of 0001; on tables created with it the key is rebuilt as is.
+// CockroachDB rejects a primary key change next to other schema changes,
+// hence its own migration. Dropping and adding the constraint in one
+// statement, unlike ALTER PRIMARY KEY, does not keep the old key as a
+// unique index, which would still allow a single event per ticker.
+//
+// There is no Down: the ticker key cannot hold the events stored since.
+var rekeyStocks = Migration{
+	Version: 15,
+	Name:    "rekey_stocks",
+	Up: []string{
+		`
+		ALTER TABLE stocks
+			DROP CONSTRAINT stocks_pkey,
+			ADD CONSTRAINT stocks_pkey PRIMARY KEY (ticker, brokerage, time, action);
+		`,
+	},
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"backend/internal/money"
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// The SQL of 0003 only understood plain "$1,234.56" amounts. This parses
+// the targets it left empty with money.Parse, the parser the sync uses, so
+// negative, parenthesised and USD amounts read the same in old and new
+// rows. Each distinct raw amount is parsed once.
+var reparseNumericTargets = Migration{
+	Version:  16,
+	Name:     "reparse_numeric_targets",
+	Backfill: backfillTargets,
+}
+
+func backfillTargets(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+	SELECT target_from FROM stocks WHERE target_from_value IS NULL AND target_from IS NOT NULL
+	UNION
+	SELECT target_to FROM stocks WHERE target_to_value IS NULL AND target_to IS NOT NULL;
+	`)
+	if err != nil {
+		return err
+	}
+
+	raws, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+
+	batch := &pgx.Batch{}
+
+	for _, raw := range raws {
+		amount, err := money.Parse(raw)
+		if err != nil {
+			continue
+		}
+
+		batch.Queue(`UPDATE stocks SET target_from_value = $2 WHERE target_from_value IS NULL AND target_from = $1;`, raw, amount)
+		batch.Queue(`UPDATE stocks SET target_to_value = $2 WHERE target_to_value IS NULL AND target_to = $1;`, raw, amount)
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return tx.SendBatch(ctx, batch).Close()
+}
@@ -0,0 +1,14 @@
+package migrations
+
+// Brokerage search reads the small brokerages directory since 0011, so the
+// trigram index 0007 put on every stocks row is no longer used.
+var dropBrokerageTrgmIndex = Migration{
+	Version: 17,
+	Name:    "drop_brokerage_trgm_index",
+	Up: []string{
+		`DROP INDEX IF EXISTS stocks@stocks_brokerage_trgm_idx;`,
+	},
+	Down: []string{
+		`CREATE INDEX IF NOT EXISTS stocks_brokerage_trgm_idx ON stocks USING GIN (brokerage gin_trgm_ops);`,
+	},
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Migration is one versioned schema change. Up and Down are executed in
+// order inside a single transaction.
+type Migration struct {
+	Version int
+	Name    string
+	Up      []string
+	Down    []string
+	// Backfill, when set, runs after Up in the same transaction, for data
+	// changes that have to reuse Go code.
+	Backfill func(ctx context.Context, tx pgx.Tx) error
+}
+
+// All lists every migration in the order they must be applied. Versions are
+// never renumbered: new changes are appended with the next version.
+var All = []Migration{
+	createStocks,
+	addNumericTargets,
+	backfillNumericTargets,
+	createSyncRuns,
+	createSyncCheckpoints,
+	addSyncRunsLimiterWait,
+	addSearchIndexes,
+	addCanonicalRatings,
+	backfillCanonicalRatings,
+	addSyncRunsUnknownRatings,
+	createBrokerages,
+	backfillBrokerages,
+	createCompanies,
+	backfillCompanies,
+	rekeyStocks,
+	reparseNumericTargets,
+	dropBrokerageTrgmIndex,
+}
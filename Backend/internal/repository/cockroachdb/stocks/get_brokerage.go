@@ -0,0 +1,41 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetBrokerage returns the directory entry of slug, or nil when there is
+// none.
+func (r *Repository) GetBrokerage(ctx context.Context, slug string) (*domain.Brokerage, error) {
+
+	var brokerage domain.Brokerage
+
+	err := r.db.QueryRow(ctx, `
+	SELECT
+		slug,
+		name,
+		first_seen_at,
+		last_seen_at
+	FROM brokerages
+	WHERE slug = $1;
+	`, slug).Scan(
+		&brokerage.Slug,
+		&brokerage.Name,
+		&brokerage.FirstSeenAt,
+		&brokerage.LastSeenAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &brokerage, nil
+}
@@ -0,0 +1,14 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetBrokerageActions(ctx context.Context, slug string, page *domain.Cursor, limit int, filter domain.StocksFilter, sort domain.StocksSort) (*domain.StocksPage, error) {
+
+	conditions, args := filterConditions(filter, []any{slug})
+	conditions = append([]string{"brokerage_slug = $1"}, conditions...)
+
+	return r.listStocks(ctx, conditions, args, page, limit, sort)
+}
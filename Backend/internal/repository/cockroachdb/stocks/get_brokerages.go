@@ -0,0 +1,62 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetBrokerages(ctx context.Context) ([]domain.BrokerageSummary, error) {
+
+	rows, err := r.db.Query(ctx, `
+	SELECT
+		b.slug,
+		b.name,
+		b.first_seen_at,
+		b.last_seen_at,
+		COUNT(s.ticker) AS actions,
+		COUNT(DISTINCT s.ticker) AS tickers,
+		COUNT(CASE WHEN s.action = $1 THEN 1 END) AS upgrades,
+		COUNT(CASE WHEN s.action = $2 THEN 1 END) AS downgrades,
+		AVG(s.target_change) AS avg_target_change
+	FROM brokerages AS b
+	LEFT JOIN stocks AS s ON s.brokerage_slug = b.slug
+	GROUP BY b.slug, b.name, b.first_seen_at, b.last_seen_at
+	ORDER BY actions DESC, b.slug ASC;
+	`, domain.StockActions["upgrade"], domain.StockActions["downgrade"])
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	brokerages := []domain.BrokerageSummary{}
+
+	for rows.Next() {
+		var b domain.BrokerageSummary
+		err := rows.Scan(
+			&b.Slug,
+			&b.Name,
+			&b.FirstSeenAt,
+			&b.LastSeenAt,
+			&b.Actions,
+			&b.Tickers,
+			&b.Upgrades,
+			&b.Downgrades,
+			&b.AvgTargetChange,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		b.UpgradeDowngradeRatio = domain.UpgradeDowngradeRatio(b.Upgrades, b.Downgrades)
+		brokerages = append(brokerages, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return brokerages, nil
+}
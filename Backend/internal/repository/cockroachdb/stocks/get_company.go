@@ -0,0 +1,41 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetCompany returns the directory entry of ticker, or nil when there is
+// none.
+func (r *Repository) GetCompany(ctx context.Context, ticker string) (*domain.Company, error) {
+
+	var company domain.Company
+
+	err := r.db.QueryRow(ctx, `
+	SELECT
+		ticker,
+		name,
+		first_seen_at,
+		last_seen_at
+	FROM companies
+	WHERE ticker = $1;
+	`, ticker).Scan(
+		&company.Ticker,
+		&company.Name,
+		&company.FirstSeenAt,
+		&company.LastSeenAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &company, nil
+}
@@ -3,34 +3,35 @@ package stocks
 import (
 	"backend/internal/domain"
 	"context"
-	"time"
+	"strings"
 )
 
-func (r *Repository) GetStats(limit int, filter *string, ticker *string) (*domain.StocksStats, error) {
+func (r *Repository) GetStats(ctx context.Context, limit int, filter domain.StocksFilter) (*domain.StocksStats, error) {
 
 	var stats domain.StocksStats
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// The counts cover every target direction; the target filter only
+	// decides which of them the page count follows.
+	counted := filter
+	counted.Target = ""
 
-	defer cancel()
+	conditions, args := filterConditions(counted, nil)
 
-	var tickerFilter any
-	if ticker != nil && *ticker != "" {
-		tickerFilter = *ticker
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
 	}
 
+	// A ticker counts once per direction it has a matching event in, the
+	// rows the listing would show with that target filter.
 	err := r.db.QueryRow(ctx, `
 	SELECT
-		COUNT(*) AS all_stocks,
-		COUNT(CASE WHEN NULLIF(REPLACE(REPLACE(target_to, '$', ''), ',', ''), '')::FLOAT >
-			NULLIF(REPLACE(REPLACE(target_from, '$', ''), ',', ''), '')::FLOAT THEN 1 END) AS up_stocks,
-		COUNT(CASE WHEN NULLIF(REPLACE(REPLACE(target_to, '$', ''), ',', ''), '')::FLOAT <
-			NULLIF(REPLACE(REPLACE(target_from, '$', ''), ',', ''), '')::FLOAT THEN 1 END) AS down_stocks,
-		COUNT(CASE WHEN NULLIF(REPLACE(REPLACE(target_to, '$', ''), ',', ''), '')::FLOAT =
-			NULLIF(REPLACE(REPLACE(target_from, '$', ''), ',', ''), '')::FLOAT THEN 1 END) AS equal_stocks
+		COUNT(DISTINCT ticker) AS all_stocks,
+		COUNT(DISTINCT CASE WHEN target_to_value > target_from_value THEN ticker END) AS up_stocks,
+		COUNT(DISTINCT CASE WHEN target_to_value < target_from_value THEN ticker END) AS down_stocks,
+		COUNT(DISTINCT CASE WHEN target_to_value = target_from_value THEN ticker END) AS equal_stocks
 	FROM stocks
-	WHERE ($1::TEXT IS NULL OR ticker LIKE ($1::TEXT || '%'));
-	`, tickerFilter).Scan(
+	`+where, args...).Scan(
 		&stats.AllStocks,
 		&stats.UpStocks,
 		&stats.DownStocks,
@@ -41,18 +42,7 @@ func (r *Repository) GetStats(limit int, filter *string, ticker *string) (*domai
 		return nil, err
 	}
 
-	if limit > 0 {
-		switch {
-		case filter != nil && *filter == "up":
-			stats.Pages = (stats.UpStocks + limit - 1) / limit
-		case filter != nil && *filter == "down":
-			stats.Pages = (stats.DownStocks + limit - 1) / limit
-		case filter != nil && *filter == "equal":
-			stats.Pages = (stats.NoChange + limit - 1) / limit
-		default:
-			stats.Pages = (stats.AllStocks + limit - 1) / limit
-		}
-	}
+	stats.SetPages(limit, filter.Target)
 
 	return &stats, nil
 
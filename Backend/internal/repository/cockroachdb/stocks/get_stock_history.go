@@ -0,0 +1,70 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetStockHistory(ctx context.Context, ticker string) (*[]domain.Stock, error) {
+
+	rows, err := r.db.Query(ctx, `
+	SELECT
+		ticker,
+		target_from_value,
+		target_to_value,
+		target_from,
+		target_to,
+		target_change,
+		company,
+		action,
+		brokerage,
+		rating_from,
+		rating_to,
+		rating_from_canonical,
+		rating_to_canonical,
+		time
+	FROM stocks
+	WHERE ticker = $1
+	ORDER BY time ASC, brokerage ASC;
+	`, ticker)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	stocks := []domain.Stock{}
+
+	for rows.Next() {
+		var stock domain.Stock
+		err := rows.Scan(
+			&stock.Ticker,
+			&stock.TargetFrom,
+			&stock.TargetTo,
+			&stock.TargetFromRaw,
+			&stock.TargetToRaw,
+			&stock.TargetChange,
+			&stock.Company,
+			&stock.Action,
+			&stock.Brokerage,
+			&stock.RatingFrom,
+			&stock.RatingTo,
+			&stock.RatingFromCanonical,
+			&stock.RatingToCanonical,
+			&stock.Time,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		stocks = append(stocks, stock)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &stocks, nil
+}
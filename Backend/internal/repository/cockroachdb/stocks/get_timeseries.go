@@ -0,0 +1,100 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GetTimeseries counts the rating events per bucket, ordered by key and
+// bucket. Every event counts, not only the latest of each ticker.
+func (r *Repository) GetTimeseries(ctx context.Context, query domain.TimeseriesQuery) ([]domain.TimeseriesPoint, error) {
+
+	// date_trunc takes the unit as text; it is picked from the known
+	// buckets so it can be inlined.
+	bucket := domain.BucketDay
+	switch query.Bucket {
+	case domain.BucketWeek, domain.BucketMonth:
+		bucket = query.Bucket
+	}
+
+	key := "''"
+	switch query.GroupBy {
+	case domain.GroupByBrokerage:
+		key = "brokerage_slug"
+	case domain.GroupByTicker:
+		key = "ticker"
+	}
+
+	args := []any{
+		domain.StockActions["upgrade"],
+		domain.StockActions["downgrade"],
+		domain.StockActions["initiated"],
+	}
+
+	var conditions []string
+
+	if query.From != nil {
+		args = append(args, *query.From)
+		conditions = append(conditions, fmt.Sprintf("time >= $%d", len(args)))
+	}
+	if query.To != nil {
+		args = append(args, *query.To)
+		conditions = append(conditions, fmt.Sprintf("time <= $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := r.db.Query(ctx, `
+	SELECT
+		COALESCE(`+key+`, '') AS key,
+		date_trunc('`+bucket+`', time AT TIME ZONE 'UTC') AS bucket,
+		COUNT(*) AS actions,
+		COUNT(CASE WHEN action = $1 THEN 1 END) AS upgrades,
+		COUNT(CASE WHEN action = $2 THEN 1 END) AS downgrades,
+		COUNT(CASE WHEN action = $3 THEN 1 END) AS initiations,
+		AVG(target_change) AS avg_target_change
+	FROM stocks
+	`+where+`
+	GROUP BY 1, 2
+	ORDER BY 1, 2;
+	`, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	points := []domain.TimeseriesPoint{}
+
+	for rows.Next() {
+		var point domain.TimeseriesPoint
+		err := rows.Scan(
+			&point.Key,
+			&point.Bucket,
+			&point.Actions,
+			&point.Upgrades,
+			&point.Downgrades,
+			&point.Initiations,
+			&point.AvgTargetChange,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		point.Bucket = point.Bucket.UTC()
+		points = append(points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
@@ -1,9 +1,48 @@
 package stocks
 
 import (
+	"backend/internal/domain"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// latestStocks keeps the most recent of the rating events matching
+// conditions for every ticker, so listings return one row per stock and
+// filters find tickers whose matching event is not their newest. Events at
+// the same time are told apart by brokerage then action, both descending,
+// like the in-memory repository.
+func latestStocks(conditions []string) string {
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return `
+	(SELECT DISTINCT ON (ticker)
+		ticker,
+		target_from_value,
+		target_to_value,
+		target_from,
+		target_to,
+		target_change,
+		company,
+		action,
+		brokerage,
+		rating_from,
+		rating_to,
+		rating_from_canonical,
+		rating_to_canonical,
+		time
+	FROM stocks
+	` + where + `
+	ORDER BY ticker, time DESC, brokerage DESC, action DESC) AS stocks
+	`
+}
+
 type Repository struct {
 	db *pgxpool.Pool
 }
@@ -13,3 +52,196 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 		db: db,
 	}
 }
+
+// missingChange stands in for a NULL target change so those stocks sort
+// last in both directions.
+const missingChange = "1e18"
+
+// sortColumn returns the expression a listing is ordered by and the type
+// its cursor key is cast back to.
+func sortColumn(sort domain.StocksSort) (string, string) {
+	switch sort.Field {
+	case domain.SortTime:
+		return "time", "TIMESTAMPTZ"
+	case domain.SortCompany:
+		return "COALESCE(company, '')", "TEXT"
+	case domain.SortBrokerage:
+		return "brokerage", "TEXT"
+	case domain.SortTargetChange:
+		if sort.Desc {
+			return "COALESCE(target_change, -" + missingChange + ")", "DECIMAL"
+		}
+		return "COALESCE(target_change, " + missingChange + ")", "DECIMAL"
+	default:
+		return "ticker", "TEXT"
+	}
+}
+
+// listStocks pages through the latest event of every ticker among the
+// events matching all conditions, ordered by sort with the ticker breaking
+// ties. A page starts next to the cursor by comparing (sort key, ticker)
+// pairs, reading in reverse for a backward cursor. The key is read back as
+// text so the cursors built from it are exact.
+func (r *Repository) listStocks(ctx context.Context, conditions []string, args []any, page *domain.Cursor, limit int, sort domain.StocksSort) (*domain.StocksPage, error) {
+
+	column, keyType := sortColumn(sort)
+
+	// Reading backwards flips both the comparison and the order.
+	descending := sort.Desc != (page != nil && page.Backward)
+
+	comparison, direction := ">", "ASC"
+	if descending {
+		comparison, direction = "<", "DESC"
+	}
+
+	where := ""
+	if page != nil {
+		args = append(args, page.Key, page.Ticker)
+		where = fmt.Sprintf("WHERE (%s, ticker) %s ($%d::%s, $%d::TEXT)", column, comparison, len(args)-1, keyType, len(args))
+	}
+
+	// One extra row tells whether the listing goes on.
+	args = append(args, limit+1)
+
+	query := `
+	SELECT
+		ticker,
+		target_from_value,
+		target_to_value,
+		target_from,
+		target_to,
+		target_change,
+		company,
+		action,
+		brokerage,
+		rating_from,
+		rating_to,
+		rating_from_canonical,
+		rating_to_canonical,
+		time,
+		(` + column + `)::STRING
+	FROM ` + latestStocks(conditions) + `
+	` + where + `
+	ORDER BY ` + column + ` ` + direction + `, ticker ` + direction + `
+	LIMIT $` + strconv.Itoa(len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var stocks []domain.Stock
+	var keys []string
+
+	for rows.Next() {
+		var stock domain.Stock
+		var key string
+
+		err := rows.Scan(
+			&stock.Ticker,
+			&stock.TargetFrom,
+			&stock.TargetTo,
+			&stock.TargetFromRaw,
+			&stock.TargetToRaw,
+			&stock.TargetChange,
+			&stock.Company,
+			&stock.Action,
+			&stock.Brokerage,
+			&stock.RatingFrom,
+			&stock.RatingTo,
+			&stock.RatingFromCanonical,
+			&stock.RatingToCanonical,
+			&stock.Time,
+			&key,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		stocks = append(stocks, stock)
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return domain.NewStocksPage(stocks, keys, page, limit, sort), nil
+}
+
+// targetCondition is the up/down/equal filter, or "" when target is unset.
+func targetCondition(target string) string {
+	switch target {
+	case domain.StockFilterUp:
+		return "target_to_value > target_from_value"
+	case domain.StockFilterDown:
+		return "target_to_value < target_from_value"
+	case domain.StockFilterEqual:
+		return "target_to_value = target_from_value"
+	default:
+		return ""
+	}
+}
+
+// likeEscaper makes user input literal inside a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// filterConditions turns filter into SQL conditions over the stocks
+// columns, appending their parameters to args.
+func filterConditions(filter domain.StocksFilter, args []any) ([]string, []any) {
+	var conditions []string
+
+	add := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if condition := targetCondition(filter.Target); condition != "" {
+		conditions = append(conditions, condition)
+	}
+	if filter.Ticker != "" {
+		add("ticker LIKE $%d", likeEscaper.Replace(filter.Ticker)+"%")
+	}
+	if filter.Brokerage != "" {
+		add("lower(brokerage) = lower($%d)", filter.Brokerage)
+	}
+	if filter.RatingTo != "" {
+		add("lower(rating_to) = lower($%d)", filter.RatingTo)
+	}
+	if filter.Rating != "" {
+		add("rating_to_canonical = $%d", filter.Rating)
+	}
+	if filter.MinRating != "" {
+		add("rating_to_canonical = ANY($%d)", domain.RatingsFrom(filter.MinRating))
+	}
+	if filter.Action != "" {
+		add("action = $%d", domain.StockActions[filter.Action])
+	}
+	if filter.Company != "" {
+		add("company ILIKE $%d", "%"+likeEscaper.Replace(filter.Company)+"%")
+	}
+	if filter.From != nil {
+		add("time >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("time <= $%d", *filter.To)
+	}
+	if filter.MinTarget != nil {
+		add("target_to_value >= $%d", *filter.MinTarget)
+	}
+	if filter.MaxTarget != nil {
+		add("target_to_value <= $%d", *filter.MaxTarget)
+	}
+	if filter.MinChange != nil {
+		add("target_change >= $%d", *filter.MinChange)
+	}
+	if filter.MaxChange != nil {
+		add("target_change <= $%d", *filter.MaxChange)
+	}
+
+	return conditions, args
+}
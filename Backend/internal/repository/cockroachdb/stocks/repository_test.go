@@ -0,0 +1,37 @@
+package stocks_test
+
+import (
+	"backend/internal/ports"
+	"backend/internal/repository/cockroachdb"
+	"backend/internal/repository/cockroachdb/stocks"
+	"backend/internal/repository/repotest"
+	"context"
+	"os"
+	"testing"
+)
+
+// The suite needs a disposable database: set COCKROACH_TEST_DSN to run it.
+// Every subtest starts from empty stocks, brokerages and companies tables.
+func TestRepository(t *testing.T) {
+	dsn := os.Getenv("COCKROACH_TEST_DSN")
+	if dsn == "" {
+		t.Skip("COCKROACH_TEST_DSN not set")
+	}
+
+	db, err := cockroachdb.ConnectDB(&dsn)
+	if err != nil {
+		t.Fatalf("ConnectDB: %v", err)
+	}
+	t.Cleanup(db.Close)
+
+	if err := cockroachdb.Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	repotest.RunStocksRepository(t, func(t *testing.T) ports.StocksRepository {
+		if _, err := db.Exec(context.Background(), `TRUNCATE stocks, brokerages, companies;`); err != nil {
+			t.Fatalf("truncating tables: %v", err)
+		}
+		return stocks.NewRepository(db)
+	})
+}
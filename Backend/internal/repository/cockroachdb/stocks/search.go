@@ -0,0 +1,90 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Search ranks stocks by ticker and company, and brokerages by their name
+// in the brokerages directory, with the tiers described in domain. $4 is the raw query for the trigram
+// functions, which lowercase on their own.
+func (r *Repository) Search(ctx context.Context, query string, limit int) ([]domain.SearchSuggestion, error) {
+
+	ticker := strings.ToUpper(query)
+	contains := "%" + likeEscaper.Replace(query) + "%"
+
+	rows, err := r.db.Query(ctx, `
+	SELECT kind, ticker, company, brokerage, match, score FROM (
+		SELECT
+			'`+domain.SuggestionStock+`' AS kind,
+			ticker,
+			COALESCE(company, '') AS company,
+			'' AS brokerage,
+			CASE
+				WHEN ticker = $1 THEN '`+domain.MatchExactTicker+`'
+				WHEN ticker LIKE $2 THEN '`+domain.MatchTickerPrefix+`'
+				ELSE '`+domain.MatchCompany+`'
+			END AS match,
+			CASE
+				WHEN ticker = $1 THEN `+strconv.Itoa(domain.ScoreExactTicker)+`::FLOAT8
+				WHEN ticker LIKE $2 THEN `+strconv.Itoa(domain.ScoreTickerPrefix)+` + `+strconv.Itoa(domain.ScoreSimilarity)+` * length($1)::FLOAT8 / length(ticker)::FLOAT8
+				WHEN company ILIKE $3 THEN `+strconv.Itoa(domain.ScoreCompanyContains)+` + `+strconv.Itoa(domain.ScoreSimilarity)+` * similarity(company, $4)
+				ELSE `+strconv.Itoa(domain.ScoreSimilarity)+` * similarity(company, $4)
+			END AS score
+		FROM (
+			SELECT DISTINCT ON (ticker) ticker, company
+			FROM stocks
+			WHERE ticker LIKE $2 OR company ILIKE $3 OR company % $4
+			ORDER BY ticker, time DESC, brokerage DESC, action DESC
+		) AS latest
+		UNION ALL
+		SELECT
+			'`+domain.SuggestionBrokerage+`',
+			'',
+			'',
+			name,
+			'`+domain.MatchBrokerage+`',
+			CASE
+				WHEN name ILIKE $3 THEN `+strconv.Itoa(domain.ScoreBrokerContains)+` + `+strconv.Itoa(domain.ScoreSimilarity)+` * similarity(name, $4)
+				ELSE `+strconv.Itoa(domain.ScoreSimilarity)+` * similarity(name, $4)
+			END
+		FROM brokerages
+		WHERE name ILIKE $3 OR name % $4
+	) AS suggestions
+	ORDER BY score DESC, kind DESC, ticker, brokerage
+	LIMIT $5
+	`, ticker, likeEscaper.Replace(ticker)+"%", contains, query, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	suggestions := []domain.SearchSuggestion{}
+
+	for rows.Next() {
+		var suggestion domain.SearchSuggestion
+
+		if err := rows.Scan(
+			&suggestion.Kind,
+			&suggestion.Ticker,
+			&suggestion.Company,
+			&suggestion.Brokerage,
+			&suggestion.Match,
+			&suggestion.Score,
+		); err != nil {
+			return nil, err
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
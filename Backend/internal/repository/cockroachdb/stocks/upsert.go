@@ -6,27 +6,34 @@ import (
 	"fmt"
 	"strings"
 	"time"
-)
 
-func (r *Repository) Upsert(stocks []domain.Stock) error {
+	"github.com/jackc/pgx/v5"
+)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *Repository) Upsert(ctx context.Context, stocks []domain.Stock) error {
 
-	defer cancel()
+	if len(stocks) == 0 {
+		return nil
+	}
 
 	var (
 		values []string
 		args   []any
 	)
 
+	// A single INSERT ... ON CONFLICT cannot touch the same row twice, so
+	// repeated events inside the batch keep only their last occurrence.
+	stocks = dedupeEvents(stocks)
+
 	for i, s := range stocks {
-		start := i*9 + 1
+		start := i*14 + 1
 
 		values = append(values,
 			fmt.Sprintf(
-				"($%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d)",
+				"($%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d)",
 				start, start+1, start+2, start+3,
 				start+4, start+5, start+6, start+7, start+8,
+				start+9, start+10, start+11, start+12, start+13,
 			),
 		)
 
@@ -34,11 +41,16 @@ func (r *Repository) Upsert(stocks []domain.Stock) error {
 			s.Ticker,
 			s.TargetFrom,
 			s.TargetTo,
+			s.TargetFromRaw,
+			s.TargetToRaw,
 			s.Company,
 			s.Action,
 			s.Brokerage,
+			domain.BrokerageSlug(s.Brokerage),
 			s.RatingFrom,
 			s.RatingTo,
+			s.RatingFromCanonical,
+			s.RatingToCanonical,
 			s.Time,
 		)
 	}
@@ -46,34 +58,142 @@ func (r *Repository) Upsert(stocks []domain.Stock) error {
 	query := `
 		INSERT INTO stocks (
 			ticker,
+			target_from_value,
+			target_to_value,
 			target_from,
 			target_to,
 			company,
 			action,
 			brokerage,
+			brokerage_slug,
 			rating_from,
 			rating_to,
+			rating_from_canonical,
+			rating_to_canonical,
 			time
 		) VALUES ` + strings.Join(values, ",") + `
-		 ON CONFLICT (ticker) DO UPDATE SET
+		 ON CONFLICT (ticker, brokerage, time, action) DO UPDATE SET
+			target_from_value = EXCLUDED.target_from_value,
+			target_to_value = EXCLUDED.target_to_value,
 			target_from = EXCLUDED.target_from,
 			target_to = EXCLUDED.target_to,
 			company = EXCLUDED.company,
-			action = EXCLUDED.action,
-			brokerage = EXCLUDED.brokerage,
 			rating_from = EXCLUDED.rating_from,
 			rating_to = EXCLUDED.rating_to,
-			time = EXCLUDED.time;
+			rating_from_canonical = EXCLUDED.rating_from_canonical,
+			rating_to_canonical = EXCLUDED.rating_to_canonical;
 	`
 
-	_, err := r.db.Exec(ctx, query, args...)
+	// The directories are updated with the events so they never list a
+	// brokerage or company whose events are missing.
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+
+		if err := upsertBrokerages(ctx, tx, domain.BrokeragesOf(stocks)); err != nil {
+			return err
+		}
+
+		return upsertCompanies(ctx, tx, domain.CompaniesOf(stocks))
+	})
+
+}
+
+// upsertBrokerages adds the brokerages to the directory, widening the seen
+// range of the ones already there.
+func upsertBrokerages(ctx context.Context, tx pgx.Tx, brokerages []domain.Brokerage) error {
+
+	if len(brokerages) == 0 {
+		return nil
+	}
+
+	var (
+		values []string
+		args   []any
+	)
+
+	for i, b := range brokerages {
+		start := i*4 + 1
+
+		values = append(values, fmt.Sprintf("($%d,$%d,$%d,$%d)", start, start+1, start+2, start+3))
+		args = append(args, b.Slug, b.Name, b.FirstSeenAt, b.LastSeenAt)
+	}
+
+	// The name follows the newest spelling, so a batch of older events
+	// keeps the current one.
+	_, err := tx.Exec(ctx, `
+		INSERT INTO brokerages (slug, name, first_seen_at, last_seen_at)
+		VALUES `+strings.Join(values, ",")+`
+		ON CONFLICT (slug) DO UPDATE SET
+			name = CASE
+				WHEN EXCLUDED.last_seen_at >= brokerages.last_seen_at THEN EXCLUDED.name
+				ELSE brokerages.name
+			END,
+			first_seen_at = least(brokerages.first_seen_at, EXCLUDED.first_seen_at),
+			last_seen_at = greatest(brokerages.last_seen_at, EXCLUDED.last_seen_at);
+	`, args...)
+
+	return err
+}
+
+// upsertCompanies adds the companies to the directory like
+// upsertBrokerages.
+func upsertCompanies(ctx context.Context, tx pgx.Tx, companies []domain.Company) error {
+
+	if len(companies) == 0 {
+		return nil
+	}
 
-	if err != nil {
+	var (
+		values []string
+		args   []any
+	)
 
-		return err
+	for i, c := range companies {
+		start := i*4 + 1
 
+		values = append(values, fmt.Sprintf("($%d,$%d,$%d,$%d)", start, start+1, start+2, start+3))
+		args = append(args, c.Ticker, c.Name, c.FirstSeenAt, c.LastSeenAt)
 	}
 
-	return nil
+	_, err := tx.Exec(ctx, `
+		INSERT INTO companies (ticker, name, first_seen_at, last_seen_at)
+		VALUES `+strings.Join(values, ",")+`
+		ON CONFLICT (ticker) DO UPDATE SET
+			name = CASE
+				WHEN EXCLUDED.last_seen_at >= companies.last_seen_at THEN EXCLUDED.name
+				ELSE companies.name
+			END,
+			first_seen_at = least(companies.first_seen_at, EXCLUDED.first_seen_at),
+			last_seen_at = greatest(companies.last_seen_at, EXCLUDED.last_seen_at);
+	`, args...)
+
+	return err
+}
+
+func dedupeEvents(stocks []domain.Stock) []domain.Stock {
+	type eventKey struct {
+		ticker    string
+		brokerage string
+		time      time.Time
+		action    string
+	}
+
+	index := make(map[eventKey]int, len(stocks))
+	events := make([]domain.Stock, 0, len(stocks))
+
+	for _, s := range stocks {
+		key := eventKey{s.Ticker, s.Brokerage, s.Time.UTC(), s.Action}
+
+		if i, ok := index[key]; ok {
+			events[i] = s
+			continue
+		}
+
+		index[key] = len(events)
+		events = append(events, s)
+	}
 
+	return events
 }
@@ -0,0 +1,30 @@
+package syncruns
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) FinishRun(ctx context.Context, run domain.SyncRun) error {
+
+	// The column is NOT NULL, so a run without unknown ratings stores an
+	// empty array.
+	unknownRatings := run.UnknownRatings
+	if unknownRatings == nil {
+		unknownRatings = []string{}
+	}
+
+	_, err := r.db.Exec(ctx, `
+	UPDATE sync_runs
+	SET
+		finished_at = $2,
+		pages_fetched = $3,
+		stocks_upserted = $4,
+		limiter_wait_ms = $5,
+		unknown_ratings = $6,
+		error = $7
+	WHERE id = $1;
+	`, run.ID, run.FinishedAt, run.PagesFetched, run.StocksUpserted, run.LimiterWaitMs, unknownRatings, run.Error)
+
+	return err
+}
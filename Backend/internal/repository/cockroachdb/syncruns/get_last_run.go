@@ -0,0 +1,49 @@
+package syncruns
+
+import (
+	"backend/internal/domain"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetLastRun returns the most recent run, or nil when the sync never ran.
+func (r *Repository) GetLastRun(ctx context.Context) (*domain.SyncRun, error) {
+
+	var run domain.SyncRun
+
+	err := r.db.QueryRow(ctx, `
+	SELECT
+		id,
+		started_at,
+		finished_at,
+		pages_fetched,
+		stocks_upserted,
+		limiter_wait_ms,
+		unknown_ratings,
+		error
+	FROM sync_runs
+	ORDER BY started_at DESC
+	LIMIT 1;
+	`).Scan(
+		&run.ID,
+		&run.StartedAt,
+		&run.FinishedAt,
+		&run.PagesFetched,
+		&run.StocksUpserted,
+		&run.LimiterWaitMs,
+		&run.UnknownRatings,
+		&run.Error,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
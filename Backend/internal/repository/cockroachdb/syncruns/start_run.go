@@ -0,0 +1,23 @@
+package syncruns
+
+import (
+	"context"
+	"time"
+)
+
+func (r *Repository) StartRun(ctx context.Context, startedAt time.Time) (int64, error) {
+
+	var id int64
+
+	err := r.db.QueryRow(ctx, `
+	INSERT INTO sync_runs (started_at)
+	VALUES ($1)
+	RETURNING id;
+	`, startedAt).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,23 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (r *Repository) GetBrokerage(ctx context.Context, slug string) (*domain.Brokerage, error) {
+
+	start := time.Now()
+	brokerage, err := r.Repository.GetBrokerage(ctx, slug)
+	if err != nil {
+		elapsed := time.Since(start)
+		fmt.Printf("[LOGGER][GET_BROKERAGE] Fetched brokerage %s in %s\n", slug, elapsed)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	fmt.Printf("[LOGGER][GET_BROKERAGE] Fetched brokerage %s in %s\n", slug, elapsed)
+	return brokerage, nil
+}
@@ -0,0 +1,25 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (r *Repository) GetBrokerageActions(ctx context.Context, slug string, page *domain.Cursor, limit int, filter domain.StocksFilter, sort domain.StocksSort) (*domain.StocksPage, error) {
+
+	start := time.Now()
+
+	stocks, err := r.Repository.GetBrokerageActions(ctx, slug, page, limit, filter, sort)
+	if err != nil {
+		elapsed := time.Since(start)
+		fmt.Printf("[LOGGER][GET_BROKERAGE_ACTIONS] Fetched actions of brokerage %s in %s\n", slug, elapsed)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	fmt.Printf("[LOGGER][GET_BROKERAGE_ACTIONS] Fetched actions of brokerage %s in %s\n", slug, elapsed)
+
+	return stocks, nil
+}
@@ -0,0 +1,23 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (r *Repository) GetBrokerages(ctx context.Context) ([]domain.BrokerageSummary, error) {
+
+	start := time.Now()
+	brokerages, err := r.Repository.GetBrokerages(ctx)
+	if err != nil {
+		elapsed := time.Since(start)
+		fmt.Printf("[LOGGER][GET_BROKERAGES] Fetched brokerages in %s\n", elapsed)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	fmt.Printf("[LOGGER][GET_BROKERAGES] Fetched %d brokerages in %s\n", len(brokerages), elapsed)
+	return brokerages, nil
+}
@@ -0,0 +1,23 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (r *Repository) GetCompany(ctx context.Context, ticker string) (*domain.Company, error) {
+
+	start := time.Now()
+	company, err := r.Repository.GetCompany(ctx, ticker)
+	if err != nil {
+		elapsed := time.Since(start)
+		fmt.Printf("[LOGGER][GET_COMPANY] Fetched company %s in %s\n", ticker, elapsed)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	fmt.Printf("[LOGGER][GET_COMPANY] Fetched company %s in %s\n", ticker, elapsed)
+	return company, nil
+}
@@ -2,14 +2,15 @@ package stocks
 
 import (
 	"backend/internal/domain"
+	"context"
 	"fmt"
 	"time"
 )
 
-func (r *Repository) GetFilterStocks(page *string, limit int, filter *string) (*domain.StocksPage, error) {
+func (r *Repository) GetFilterStocks(ctx context.Context, page *domain.Cursor, limit int, filter domain.StocksFilter, sort domain.StocksSort) (*domain.StocksPage, error) {
 
 	start := time.Now()
-	stocksPage, err := r.Repository.GetFilterStocks(page, limit, filter)
+	stocksPage, err := r.Repository.GetFilterStocks(ctx, page, limit, filter, sort)
 	if err != nil {
 		elapsed := time.Since(start)
 		fmt.Printf("[LOGGER][GET_UP_STOCKS] Fetched up stocks page in %s\n", elapsed)
@@ -0,0 +1,23 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (r *Repository) GetLatestRatings(ctx context.Context, ticker string) (*[]domain.Stock, error) {
+
+	start := time.Now()
+	stocks, err := r.Repository.GetLatestRatings(ctx, ticker)
+	if err != nil {
+		elapsed := time.Since(start)
+		fmt.Printf("[LOGGER][GET_LATEST_RATINGS] Fetched latest ratings in %s\n", elapsed)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	fmt.Printf("[LOGGER][GET_LATEST_RATINGS] Fetched %d latest ratings in %s\n", len(*stocks), elapsed)
+	return stocks, nil
+}
@@ -2,15 +2,16 @@ package stocks
 
 import (
 	"backend/internal/domain"
+	"context"
 	"fmt"
 	"time"
 )
 
-func (r *Repository) GetStats(limit int, filter *string, ticker *string) (*domain.StocksStats, error) {
+func (r *Repository) GetStats(ctx context.Context, limit int, filter domain.StocksFilter) (*domain.StocksStats, error) {
 
 	startTime := time.Now()
 
-	stats, err := r.Repository.GetStats(limit, filter, ticker)
+	stats, err := r.Repository.GetStats(ctx, limit, filter)
 	if err != nil {
 		elapsed := time.Since(startTime)
 		fmt.Printf("[LOGGER][GET_STATS] Fetched stocks stats in %s\n", elapsed)
@@ -0,0 +1,23 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (r *Repository) GetStockHistory(ctx context.Context, ticker string) (*[]domain.Stock, error) {
+
+	start := time.Now()
+	stocks, err := r.Repository.GetStockHistory(ctx, ticker)
+	if err != nil {
+		elapsed := time.Since(start)
+		fmt.Printf("[LOGGER][GET_STOCK_HISTORY] Fetched history of %s in %s\n", ticker, elapsed)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	fmt.Printf("[LOGGER][GET_STOCK_HISTORY] Fetched %d events of %s in %s\n", len(*stocks), ticker, elapsed)
+	return stocks, nil
+}
@@ -2,14 +2,15 @@ package stocks
 
 import (
 	"backend/internal/domain"
+	"context"
 	"fmt"
 	"time"
 )
 
-func (r *Repository) GetStocks(page *string, limit int) (*domain.StocksPage, error) {
+func (r *Repository) GetStocks(ctx context.Context, page *domain.Cursor, limit int, sort domain.StocksSort) (*domain.StocksPage, error) {
 
 	start := time.Now()
-	stocksPage, err := r.Repository.GetStocks(page, limit)
+	stocksPage, err := r.Repository.GetStocks(ctx, page, limit, sort)
 
 	if err != nil {
 		elapsed := time.Since(start)
@@ -0,0 +1,23 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (r *Repository) GetTimeseries(ctx context.Context, query domain.TimeseriesQuery) ([]domain.TimeseriesPoint, error) {
+
+	start := time.Now()
+	points, err := r.Repository.GetTimeseries(ctx, query)
+	if err != nil {
+		elapsed := time.Since(start)
+		fmt.Printf("[LOGGER][GET_TIMESERIES] Fetched %s time series in %s\n", query.Bucket, elapsed)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	fmt.Printf("[LOGGER][GET_TIMESERIES] Fetched %d %s points in %s\n", len(points), query.Bucket, elapsed)
+	return points, nil
+}
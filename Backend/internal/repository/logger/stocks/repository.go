@@ -1,12 +1,12 @@
 package stocks
 
-import "backend/internal/repository/cockroachdb/stocks"
+import "backend/internal/ports"
 
 type Repository struct {
-	Repository *stocks.Repository
+	Repository ports.StocksRepository
 }
 
-func NewLoggerRepository(repo *stocks.Repository) *Repository {
+func NewLoggerRepository(repo ports.StocksRepository) *Repository {
 	return &Repository{
 		Repository: repo,
 	}
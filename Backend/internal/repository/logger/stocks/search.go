@@ -0,0 +1,23 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (r *Repository) Search(ctx context.Context, query string, limit int) ([]domain.SearchSuggestion, error) {
+
+	start := time.Now()
+	suggestions, err := r.Repository.Search(ctx, query, limit)
+	if err != nil {
+		elapsed := time.Since(start)
+		fmt.Printf("[LOGGER][SEARCH] Searched %q in %s\n", query, elapsed)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	fmt.Printf("[LOGGER][SEARCH] Found %d suggestions for %q in %s\n", len(suggestions), query, elapsed)
+	return suggestions, nil
+}
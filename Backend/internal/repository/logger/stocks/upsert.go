@@ -2,16 +2,17 @@ package stocks
 
 import (
 	"backend/internal/domain"
+	"context"
 	"fmt"
 	"time"
 )
 
-func (r *Repository) Upsert(stocks []domain.Stock) error {
+func (r *Repository) Upsert(ctx context.Context, stocks []domain.Stock) error {
 
 	start := time.Now()
 	fmt.Printf("[LOGGER][UPSERT] Upsert start for %d stocks\n", len(stocks))
 
-	err := r.Repository.Upsert(stocks)
+	err := r.Repository.Upsert(ctx, stocks)
 
 	if err != nil {
 
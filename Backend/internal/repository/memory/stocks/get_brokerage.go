@@ -0,0 +1,23 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetBrokerage(ctx context.Context, slug string) (*domain.Brokerage, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	brokerage, ok := r.brokerages[slug]
+	if !ok {
+		return nil, nil
+	}
+
+	return &brokerage, nil
+}
@@ -0,0 +1,79 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"sort"
+)
+
+func (r *Repository) GetBrokerages(ctx context.Context) ([]domain.BrokerageSummary, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type totals struct {
+		tickers     map[string]bool
+		changeSum   float64
+		changeCount int
+	}
+
+	summaries := make(map[string]*domain.BrokerageSummary, len(r.brokerages))
+	counts := make(map[string]*totals, len(r.brokerages))
+
+	for slug, b := range r.brokerages {
+		summaries[slug] = &domain.BrokerageSummary{Brokerage: b}
+		counts[slug] = &totals{tickers: map[string]bool{}}
+	}
+
+	for _, event := range r.events {
+		slug := domain.BrokerageSlug(event.Brokerage)
+		summary, ok := summaries[slug]
+		if !ok {
+			continue
+		}
+		total := counts[slug]
+
+		summary.Actions++
+		total.tickers[event.Ticker] = true
+
+		switch event.Action {
+		case domain.StockActions["upgrade"]:
+			summary.Upgrades++
+		case domain.StockActions["downgrade"]:
+			summary.Downgrades++
+		}
+
+		if event.TargetChange != nil {
+			total.changeSum += *event.TargetChange
+			total.changeCount++
+		}
+	}
+
+	brokerages := make([]domain.BrokerageSummary, 0, len(summaries))
+
+	for slug, summary := range summaries {
+		total := counts[slug]
+
+		summary.Tickers = len(total.tickers)
+		summary.UpgradeDowngradeRatio = domain.UpgradeDowngradeRatio(summary.Upgrades, summary.Downgrades)
+		if total.changeCount > 0 {
+			avg := total.changeSum / float64(total.changeCount)
+			summary.AvgTargetChange = &avg
+		}
+
+		brokerages = append(brokerages, *summary)
+	}
+
+	sort.Slice(brokerages, func(i, j int) bool {
+		if brokerages[i].Actions != brokerages[j].Actions {
+			return brokerages[i].Actions > brokerages[j].Actions
+		}
+		return brokerages[i].Slug < brokerages[j].Slug
+	})
+
+	return brokerages, nil
+}
@@ -0,0 +1,23 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetCompany(ctx context.Context, ticker string) (*domain.Company, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	company, ok := r.companies[ticker]
+	if !ok {
+		return nil, nil
+	}
+
+	return &company, nil
+}
@@ -0,0 +1,22 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetFilterStocks(ctx context.Context, page *domain.Cursor, limit int, filter domain.StocksFilter, sort domain.StocksSort) (*domain.StocksPage, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stocks := r.latest(func(event domain.Stock) bool {
+		return matches(event, filter)
+	})
+
+	return paginate(stocks, page, limit, sort), nil
+}
@@ -0,0 +1,46 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"sort"
+)
+
+func (r *Repository) GetLatestRatings(ctx context.Context, ticker string) (*[]domain.Stock, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type ratingKey struct{ ticker, brokerage string }
+	latest := make(map[ratingKey]domain.Stock)
+
+	for _, event := range r.events {
+		if ticker != "" && event.Ticker != ticker {
+			continue
+		}
+
+		key := ratingKey{event.Ticker, domain.BrokerageSlug(event.Brokerage)}
+		current, ok := latest[key]
+		if !ok || newer(event, current) {
+			latest[key] = event
+		}
+	}
+
+	stocks := make([]domain.Stock, 0, len(latest))
+	for _, stock := range latest {
+		stocks = append(stocks, stock)
+	}
+
+	sort.Slice(stocks, func(i, j int) bool {
+		if stocks[i].Ticker != stocks[j].Ticker {
+			return stocks[i].Ticker < stocks[j].Ticker
+		}
+		return domain.BrokerageSlug(stocks[i].Brokerage) < domain.BrokerageSlug(stocks[j].Brokerage)
+	})
+
+	return &stocks, nil
+}
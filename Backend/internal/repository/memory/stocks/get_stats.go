@@ -0,0 +1,56 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetStats(ctx context.Context, limit int, filter domain.StocksFilter) (*domain.StocksStats, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var stats domain.StocksStats
+
+	// The counts cover every target direction; the target filter only
+	// decides which of them the page count follows.
+	counted := filter
+	counted.Target = ""
+
+	// A ticker counts once per direction it has a matching event in, the
+	// rows the listing would show with that target filter.
+	all := make(map[string]bool)
+	up := make(map[string]bool)
+	down := make(map[string]bool)
+	equal := make(map[string]bool)
+
+	for _, event := range r.events {
+		if !matches(event, counted) {
+			continue
+		}
+
+		all[event.Ticker] = true
+
+		switch {
+		case matchesTarget(event, domain.StockFilterUp):
+			up[event.Ticker] = true
+		case matchesTarget(event, domain.StockFilterDown):
+			down[event.Ticker] = true
+		case matchesTarget(event, domain.StockFilterEqual):
+			equal[event.Ticker] = true
+		}
+	}
+
+	stats.AllStocks = len(all)
+	stats.UpStocks = len(up)
+	stats.DownStocks = len(down)
+	stats.NoChange = len(equal)
+
+	stats.SetPages(limit, filter.Target)
+
+	return &stats, nil
+}
@@ -0,0 +1,34 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"sort"
+)
+
+func (r *Repository) GetStockHistory(ctx context.Context, ticker string) (*[]domain.Stock, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stocks := []domain.Stock{}
+
+	for _, event := range r.events {
+		if event.Ticker == ticker {
+			stocks = append(stocks, event)
+		}
+	}
+
+	sort.Slice(stocks, func(i, j int) bool {
+		if !stocks[i].Time.Equal(stocks[j].Time) {
+			return stocks[i].Time.Before(stocks[j].Time)
+		}
+		return stocks[i].Brokerage < stocks[j].Brokerage
+	})
+
+	return &stocks, nil
+}
@@ -0,0 +1,18 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) GetStocks(ctx context.Context, page *domain.Cursor, limit int, sort domain.StocksSort) (*domain.StocksPage, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return paginate(r.latest(anyEvent), page, limit, sort), nil
+}
@@ -0,0 +1,90 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"sort"
+	"time"
+)
+
+func (r *Repository) GetTimeseries(ctx context.Context, query domain.TimeseriesQuery) ([]domain.TimeseriesPoint, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type pointKey struct {
+		key    string
+		bucket time.Time
+	}
+
+	type totals struct {
+		point       domain.TimeseriesPoint
+		changeSum   float64
+		changeCount int
+	}
+
+	byKey := make(map[pointKey]*totals)
+
+	for _, event := range r.events {
+		if query.From != nil && event.Time.Before(*query.From) {
+			continue
+		}
+		if query.To != nil && event.Time.After(*query.To) {
+			continue
+		}
+
+		key := ""
+		switch query.GroupBy {
+		case domain.GroupByBrokerage:
+			key = domain.BrokerageSlug(event.Brokerage)
+		case domain.GroupByTicker:
+			key = event.Ticker
+		}
+
+		k := pointKey{key, domain.TruncateTime(event.Time, query.Bucket)}
+		total, ok := byKey[k]
+		if !ok {
+			total = &totals{point: domain.TimeseriesPoint{Key: k.key, Bucket: k.bucket}}
+			byKey[k] = total
+		}
+
+		total.point.Actions++
+
+		switch event.Action {
+		case domain.StockActions["upgrade"]:
+			total.point.Upgrades++
+		case domain.StockActions["downgrade"]:
+			total.point.Downgrades++
+		case domain.StockActions["initiated"]:
+			total.point.Initiations++
+		}
+
+		if event.TargetChange != nil {
+			total.changeSum += *event.TargetChange
+			total.changeCount++
+		}
+	}
+
+	points := make([]domain.TimeseriesPoint, 0, len(byKey))
+
+	for _, total := range byKey {
+		if total.changeCount > 0 {
+			avg := total.changeSum / float64(total.changeCount)
+			total.point.AvgTargetChange = &avg
+		}
+		points = append(points, total.point)
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Key != points[j].Key {
+			return points[i].Key < points[j].Key
+		}
+		return points[i].Bucket.Before(points[j].Bucket)
+	})
+
+	return points, nil
+}
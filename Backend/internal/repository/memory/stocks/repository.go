@@ -0,0 +1,255 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"cmp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type eventKey struct {
+	ticker    string
+	brokerage string
+	time      time.Time
+	action    string
+}
+
+// Repository keeps rating events in memory. It mirrors the semantics of the
+// CockroachDB repository (one row per event, listings showing the latest
+// matching event per ticker) and is safe for concurrent use.
+type Repository struct {
+	mu         sync.RWMutex
+	events     map[eventKey]domain.Stock
+	brokerages map[string]domain.Brokerage
+	companies  map[string]domain.Company
+}
+
+func NewRepository() *Repository {
+	return &Repository{
+		events:     make(map[eventKey]domain.Stock),
+		brokerages: make(map[string]domain.Brokerage),
+		companies:  make(map[string]domain.Company),
+	}
+}
+
+func keyOf(stock domain.Stock) eventKey {
+	return eventKey{stock.Ticker, stock.Brokerage, stock.Time.UTC(), stock.Action}
+}
+
+// latest returns the newest of the events keep accepts for every ticker,
+// ordered by ticker. The caller must hold the lock.
+func (r *Repository) latest(keep func(domain.Stock) bool) []domain.Stock {
+	byTicker := make(map[string]domain.Stock)
+
+	for _, event := range r.events {
+		if !keep(event) {
+			continue
+		}
+
+		current, ok := byTicker[event.Ticker]
+		if !ok || newer(event, current) {
+			byTicker[event.Ticker] = event
+		}
+	}
+
+	stocks := make([]domain.Stock, 0, len(byTicker))
+	for _, stock := range byTicker {
+		stocks = append(stocks, stock)
+	}
+
+	sort.Slice(stocks, func(i, j int) bool {
+		return stocks[i].Ticker < stocks[j].Ticker
+	})
+
+	return stocks
+}
+
+// anyEvent keeps every event.
+func anyEvent(domain.Stock) bool { return true }
+
+// newer orders events by time. Ties, which the database leaves undefined,
+// are broken by brokerage and action so results stay deterministic.
+func newer(a, b domain.Stock) bool {
+	if !a.Time.Equal(b.Time) {
+		return a.Time.After(b.Time)
+	}
+	if a.Brokerage != b.Brokerage {
+		return a.Brokerage > b.Brokerage
+	}
+	return a.Action > b.Action
+}
+
+// matchesTarget applies the up/down/equal target filter. An empty target
+// matches everything and missing targets never match a comparison, like
+// NULL in SQL.
+func matchesTarget(stock domain.Stock, target string) bool {
+	switch target {
+	case domain.StockFilterUp, domain.StockFilterDown, domain.StockFilterEqual:
+	default:
+		return true
+	}
+
+	if stock.TargetFrom == nil || stock.TargetTo == nil {
+		return false
+	}
+
+	from, to := *stock.TargetFrom, *stock.TargetTo
+
+	switch target {
+	case domain.StockFilterUp:
+		return to > from
+	case domain.StockFilterDown:
+		return to < from
+	default:
+		return to == from
+	}
+}
+
+// matches applies every field of filter to an event, mirroring the SQL
+// conditions of the CockroachDB repository.
+func matches(stock domain.Stock, filter domain.StocksFilter) bool {
+	switch {
+	case !matchesTarget(stock, filter.Target):
+		return false
+	case filter.Ticker != "" && !hasTickerPrefix(stock, filter.Ticker):
+		return false
+	case filter.Brokerage != "" && !strings.EqualFold(stock.Brokerage, filter.Brokerage):
+		return false
+	case filter.RatingTo != "" && !strings.EqualFold(stock.RatingTo, filter.RatingTo):
+		return false
+	case filter.Rating != "" && !canonicalIn(stock.RatingToCanonical, filter.Rating):
+		return false
+	case filter.MinRating != "" && !canonicalIn(stock.RatingToCanonical, domain.RatingsFrom(filter.MinRating)...):
+		return false
+	case filter.Action != "" && stock.Action != domain.StockActions[filter.Action]:
+		return false
+	case filter.Company != "" && !strings.Contains(strings.ToLower(stock.Company), strings.ToLower(filter.Company)):
+		return false
+	case filter.From != nil && stock.Time.Before(*filter.From):
+		return false
+	case filter.To != nil && stock.Time.After(*filter.To):
+		return false
+	}
+
+	return inRange(stock.TargetTo, filter.MinTarget, filter.MaxTarget) &&
+		inRange(stock.TargetChange, filter.MinChange, filter.MaxChange)
+}
+
+// inRange reports whether value lies within the set bounds. A missing value
+// is only in range when there are no bounds.
+func inRange(value, min, max *float64) bool {
+	if min == nil && max == nil {
+		return true
+	}
+	if value == nil {
+		return false
+	}
+	return (min == nil || *value >= *min) && (max == nil || *value <= *max)
+}
+
+// paginate orders the stocks by sort and keeps up to limit of them next to
+// the cursor, walking backwards for a backward cursor, like the
+// CockroachDB repository.
+func paginate(stocks []domain.Stock, page *domain.Cursor, limit int, sort domain.StocksSort) *domain.StocksPage {
+	sorted := slices.Clone(stocks)
+	slices.SortFunc(sorted, func(a, b domain.Stock) int {
+		return compareSorted(sortKey(a, sort), a.Ticker, sortKey(b, sort), b.Ticker, sort)
+	})
+
+	backward := page != nil && page.Backward
+	if backward {
+		slices.Reverse(sorted)
+	}
+
+	var rows []domain.Stock
+	var keys []string
+
+	for _, stock := range sorted {
+		// One extra row tells whether the listing goes on.
+		if len(rows) > limit {
+			break
+		}
+
+		key := sortKey(stock, sort)
+
+		if page != nil {
+			c := compareSorted(key, stock.Ticker, page.Key, page.Ticker, sort)
+			if (!backward && c <= 0) || (backward && c >= 0) {
+				continue
+			}
+		}
+		rows = append(rows, stock)
+		keys = append(keys, key)
+	}
+
+	return domain.NewStocksPage(rows, keys, page, limit, sort)
+}
+
+// missingChange stands in for a NULL target change so those stocks sort
+// last in both directions, as in the CockroachDB repository.
+const missingChange = 1e18
+
+// sortKey formats the value a stock is sorted by so it round-trips through
+// a cursor.
+func sortKey(stock domain.Stock, sort domain.StocksSort) string {
+	switch sort.Field {
+	case domain.SortTime:
+		return stock.Time.UTC().Format(time.RFC3339Nano)
+	case domain.SortCompany:
+		return stock.Company
+	case domain.SortBrokerage:
+		return stock.Brokerage
+	case domain.SortTargetChange:
+		change := missingChange
+		if sort.Desc {
+			change = -missingChange
+		}
+		if stock.TargetChange != nil {
+			change = *stock.TargetChange
+		}
+		return strconv.FormatFloat(change, 'g', -1, 64)
+	default:
+		return stock.Ticker
+	}
+}
+
+// compareSorted compares two (key, ticker) positions in sort order.
+func compareSorted(keyA, tickerA, keyB, tickerB string, sort domain.StocksSort) int {
+	c := compareKeys(keyA, keyB, sort.Field)
+	if c == 0 {
+		c = strings.Compare(tickerA, tickerB)
+	}
+	if sort.Desc {
+		return -c
+	}
+	return c
+}
+
+func compareKeys(a, b string, field string) int {
+	switch field {
+	case domain.SortTime:
+		ta, _ := time.Parse(time.RFC3339Nano, a)
+		tb, _ := time.Parse(time.RFC3339Nano, b)
+		return ta.Compare(tb)
+	case domain.SortTargetChange:
+		fa, _ := strconv.ParseFloat(a, 64)
+		fb, _ := strconv.ParseFloat(b, 64)
+		return cmp.Compare(fa, fb)
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// canonicalIn reports whether a canonical rating is one of ratings. Unknown
+// ratings match nothing, like NULL in SQL.
+func canonicalIn(canonical *string, ratings ...string) bool {
+	return canonical != nil && slices.Contains(ratings, *canonical)
+}
+
+func hasTickerPrefix(stock domain.Stock, prefix string) bool {
+	return strings.HasPrefix(stock.Ticker, prefix)
+}
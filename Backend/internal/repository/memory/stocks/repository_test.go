@@ -0,0 +1,14 @@
+package stocks_test
+
+import (
+	"backend/internal/ports"
+	"backend/internal/repository/memory/stocks"
+	"backend/internal/repository/repotest"
+	"testing"
+)
+
+func TestRepository(t *testing.T) {
+	repotest.RunStocksRepository(t, func(t *testing.T) ports.StocksRepository {
+		return stocks.NewRepository()
+	})
+}
@@ -0,0 +1,119 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+func (r *Repository) Search(ctx context.Context, query string, limit int) ([]domain.SearchSuggestion, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ticker := strings.ToUpper(query)
+	lower := strings.ToLower(query)
+
+	suggestions := []domain.SearchSuggestion{}
+
+	for _, stock := range r.latest(anyEvent) {
+		suggestion := domain.SearchSuggestion{Kind: domain.SuggestionStock, Ticker: stock.Ticker, Company: stock.Company}
+		contains := strings.Contains(strings.ToLower(stock.Company), lower)
+		sim := similarity(stock.Company, query)
+
+		switch {
+		case stock.Ticker == ticker:
+			suggestion.Match, suggestion.Score = domain.MatchExactTicker, domain.ScoreExactTicker
+		case strings.HasPrefix(stock.Ticker, ticker):
+			suggestion.Match = domain.MatchTickerPrefix
+			suggestion.Score = domain.ScoreTickerPrefix + domain.ScoreSimilarity*float64(len(ticker))/float64(len(stock.Ticker))
+		case contains:
+			suggestion.Match, suggestion.Score = domain.MatchCompany, domain.ScoreCompanyContains+domain.ScoreSimilarity*sim
+		case sim >= domain.SimilarityThreshold:
+			suggestion.Match, suggestion.Score = domain.MatchCompany, domain.ScoreSimilarity*sim
+		default:
+			continue
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	for _, known := range r.brokerages {
+		brokerage := known.Name
+		suggestion := domain.SearchSuggestion{Kind: domain.SuggestionBrokerage, Brokerage: brokerage, Match: domain.MatchBrokerage}
+		sim := similarity(brokerage, query)
+
+		switch {
+		case strings.Contains(strings.ToLower(brokerage), lower):
+			suggestion.Score = domain.ScoreBrokerContains + domain.ScoreSimilarity*sim
+		case sim >= domain.SimilarityThreshold:
+			suggestion.Score = domain.ScoreSimilarity * sim
+		default:
+			continue
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	slices.SortFunc(suggestions, func(a, b domain.SearchSuggestion) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		if c := strings.Compare(b.Kind, a.Kind); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Ticker, b.Ticker); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Brokerage, b.Brokerage)
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions, nil
+}
+
+// similarity is pg_trgm's similarity: the share of trigrams two strings
+// have in common, taking every word lowercased and padded with two spaces
+// before and one after.
+func similarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for trigram := range ta {
+		if tb[trigram] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,60 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (r *Repository) Upsert(ctx context.Context, stocks []domain.Stock) error {
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stock := range stocks {
+		stock.Time = stock.Time.UTC()
+		stock.TargetChange = domain.PercentChange(stock.TargetFrom, stock.TargetTo)
+		r.events[keyOf(stock)] = stock
+	}
+
+	for _, b := range domain.BrokeragesOf(stocks) {
+		b.FirstSeenAt, b.LastSeenAt = b.FirstSeenAt.UTC(), b.LastSeenAt.UTC()
+
+		current, ok := r.brokerages[b.Slug]
+		if ok {
+			// The name follows the newest spelling, as in the CockroachDB
+			// repository.
+			if b.LastSeenAt.Before(current.LastSeenAt) {
+				b.Name = current.Name
+				b.LastSeenAt = current.LastSeenAt
+			}
+			if current.FirstSeenAt.Before(b.FirstSeenAt) {
+				b.FirstSeenAt = current.FirstSeenAt
+			}
+		}
+
+		r.brokerages[b.Slug] = b
+	}
+
+	for _, c := range domain.CompaniesOf(stocks) {
+		c.FirstSeenAt, c.LastSeenAt = c.FirstSeenAt.UTC(), c.LastSeenAt.UTC()
+
+		current, ok := r.companies[c.Ticker]
+		if ok {
+			if c.LastSeenAt.Before(current.LastSeenAt) {
+				c.Name = current.Name
+				c.LastSeenAt = current.LastSeenAt
+			}
+			if current.FirstSeenAt.Before(c.FirstSeenAt) {
+				c.FirstSeenAt = current.FirstSeenAt
+			}
+		}
+
+		r.companies[c.Ticker] = c
+	}
+
+	return nil
+}
@@ -0,0 +1,850 @@
+// Package repotest holds the conformance suite every ports.StocksRepository
+// implementation must pass, so the in-memory repository stays faithful to
+// the CockroachDB one.
+package repotest
+
+import (
+	"backend/internal/domain"
+	"backend/internal/ports"
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// NewStocksRepository returns an empty repository for one subtest.
+type NewStocksRepository func(t *testing.T) ports.StocksRepository
+
+var base = time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+
+// Stock builds a rating event with numeric and raw targets filled in the way
+// the sync stores them.
+func Stock(ticker, brokerage, action, ratingTo string, from, to float64, at time.Time) domain.Stock {
+	return domain.Stock{
+		Ticker:              ticker,
+		TargetFrom:          &from,
+		TargetTo:            &to,
+		TargetFromRaw:       fmt.Sprintf("$%.2f", from),
+		TargetToRaw:         fmt.Sprintf("$%.2f", to),
+		TargetChange:        domain.PercentChange(&from, &to),
+		Company:             ticker + " Inc.",
+		Action:              action,
+		Brokerage:           brokerage,
+		RatingFrom:          "Hold",
+		RatingTo:            ratingTo,
+		RatingFromCanonical: domain.NormalizeRating("Hold"),
+		RatingToCanonical:   domain.NormalizeRating(ratingTo),
+		Time:                at,
+	}
+}
+
+// RunStocksRepository runs the whole suite against the implementation
+// returned by newRepo.
+func RunStocksRepository(t *testing.T, newRepo NewStocksRepository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo ports.StocksRepository)
+	}{
+		{"UpsertKeepsHistory", testUpsertKeepsHistory},
+		{"UpsertUpdatesSameEvent", testUpsertUpdatesSameEvent},
+		{"GetStocksPaginatesByTicker", testGetStocksPaginates},
+		{"GetStocksPagesBackward", testGetStocksPagesBackward},
+		{"GetStocksSorts", testGetStocksSorts},
+		{"GetFilterStocks", testGetFilterStocks},
+		{"GetFilterStocksFields", testGetFilterStocksFields},
+		{"GetFilterStocksByTicker", testGetFilterStocksByTicker},
+		{"GetFilterStocksMatchesEvents", testGetFilterStocksMatchesEvents},
+		{"GetStats", testGetStats},
+		{"GetLatestRatings", testGetLatestRatings},
+		{"GetLatestRatingsBySlug", testGetLatestRatingsBySlug},
+		{"GetStockHistoryUnknownTicker", testGetStockHistoryUnknown},
+		{"SearchRanks", testSearchRanks},
+		{"SearchBrokerageOnce", testSearchBrokerageOnce},
+		{"GetBrokerages", testGetBrokerages},
+		{"GetBrokerageActions", testGetBrokerageActions},
+		{"GetCompany", testGetCompany},
+		{"GetTimeseries", testGetTimeseries},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+func upsert(t *testing.T, repo ports.StocksRepository, stocks ...domain.Stock) {
+	t.Helper()
+
+	if err := repo.Upsert(context.Background(), stocks); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+}
+
+func tickers(stocks []domain.Stock) []string {
+	out := make([]string, 0, len(stocks))
+	for _, s := range stocks {
+		out = append(out, s.Ticker)
+	}
+	return out
+}
+
+func assertTickers(t *testing.T, got []domain.Stock, want ...string) {
+	t.Helper()
+
+	gotTickers := tickers(got)
+	if fmt.Sprint(gotTickers) != fmt.Sprint(want) {
+		t.Fatalf("tickers = %v, want %v", gotTickers, want)
+	}
+}
+
+// seedTargets stores one ticker going up, one down, one unchanged and one
+// with an unparsed target for each of the AA and BB prefixes.
+func seedTargets(t *testing.T, repo ports.StocksRepository) {
+	t.Helper()
+
+	unparsed := Stock("AAD", "Citi", "initiated by", "Buy", 0, 10, base)
+	unparsed.TargetFrom = nil
+	unparsed.TargetFromRaw = "n/a"
+	unparsed.TargetChange = nil
+
+	upsert(t, repo,
+		Stock("AAA", "Citi", "upgraded by", "Buy", 10, 12, base),
+		Stock("AAB", "Citi", "downgraded by", "Sell", 10, 8, base),
+		Stock("AAC", "Citi", "reiterated by", "Hold", 10, 10, base),
+		unparsed,
+		Stock("BBA", "Citi", "upgraded by", "Buy", 20, 30, base),
+		Stock("BBB", "Citi", "downgraded by", "Sell", 20, 10, base),
+		Stock("BBC", "Citi", "reiterated by", "Hold", 20, 20, base),
+	)
+}
+
+func testUpsertKeepsHistory(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	upsert(t, repo,
+		Stock("AAPL", "Citi", "upgraded by", "Buy", 100, 120, base),
+		Stock("AAPL", "Jefferies", "target raised by", "Strong-Buy", 120, 150, base.Add(time.Hour)),
+		Stock("MSFT", "Citi", "initiated by", "Buy", 300, 320, base),
+	)
+
+	page, err := repo.GetStocks(ctx, nil, 10, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetStocks: %v", err)
+	}
+
+	assertTickers(t, page.Items, "AAPL", "MSFT")
+
+	if page.Items[0].Brokerage != "Jefferies" {
+		t.Fatalf("latest AAPL event from %q, want Jefferies", page.Items[0].Brokerage)
+	}
+
+	history, err := repo.GetStockHistory(ctx, "AAPL")
+	if err != nil {
+		t.Fatalf("GetStockHistory: %v", err)
+	}
+
+	if len(*history) != 2 {
+		t.Fatalf("history has %d events, want 2", len(*history))
+	}
+
+	first, second := (*history)[0], (*history)[1]
+
+	if first.Brokerage != "Citi" || second.Brokerage != "Jefferies" {
+		t.Fatalf("history order = %s, %s; want Citi, Jefferies", first.Brokerage, second.Brokerage)
+	}
+
+	if !second.Time.Equal(base.Add(time.Hour)) {
+		t.Fatalf("event time = %s, want %s", second.Time, base.Add(time.Hour))
+	}
+
+	if second.TargetFromRaw != "$120.00" || second.TargetTo == nil || *second.TargetTo != 150 {
+		t.Fatalf("targets not stored: %+v", second)
+	}
+
+	if second.TargetChange == nil || math.Abs(*second.TargetChange-25) > 0.001 {
+		t.Fatalf("target change = %v, want 25", second.TargetChange)
+	}
+}
+
+func testUpsertUpdatesSameEvent(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	upsert(t, repo, Stock("AAPL", "Citi", "upgraded by", "Buy", 100, 120, base))
+	upsert(t, repo, Stock("AAPL", "Citi", "upgraded by", "Strong-Buy", 100, 130, base))
+
+	history, err := repo.GetStockHistory(ctx, "AAPL")
+	if err != nil {
+		t.Fatalf("GetStockHistory: %v", err)
+	}
+
+	if len(*history) != 1 {
+		t.Fatalf("history has %d events, want 1", len(*history))
+	}
+
+	if got := (*history)[0]; got.RatingTo != "Strong-Buy" || *got.TargetTo != 130 {
+		t.Fatalf("event not updated: %+v", got)
+	}
+}
+
+func testGetStocksPaginates(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	for _, ticker := range []string{"EEE", "AAA", "CCC", "BBB", "DDD"} {
+		upsert(t, repo, Stock(ticker, "Citi", "upgraded by", "Buy", 10, 11, base))
+	}
+
+	var page *domain.Cursor
+	var got [][]string
+
+	for i := 0; i < 4; i++ {
+		stocksPage, err := repo.GetStocks(ctx, page, 2, domain.DefaultStocksSort)
+		if err != nil {
+			t.Fatalf("GetStocks: %v", err)
+		}
+
+		got = append(got, tickers(stocksPage.Items))
+
+		if stocksPage.Next == nil {
+			break
+		}
+		page = stocksPage.Next
+	}
+
+	want := "[[AAA BBB] [CCC DDD] [EEE]]"
+	if fmt.Sprint(got) != want {
+		t.Fatalf("pages = %v, want %s", got, want)
+	}
+}
+
+// testGetStocksPagesBackward walks to the last page and back again with the
+// Prev cursors, which must give the same pages in reverse.
+func testGetStocksPagesBackward(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	for _, ticker := range []string{"AAA", "BBB", "CCC", "DDD", "EEE"} {
+		upsert(t, repo, Stock(ticker, "Citi", "upgraded by", "Buy", 10, 11, base))
+	}
+
+	sort := domain.StocksSort{Field: domain.SortTicker, Desc: true}
+
+	first, err := repo.GetStocks(ctx, nil, 2, sort)
+	if err != nil {
+		t.Fatalf("GetStocks: %v", err)
+	}
+
+	if first.Prev != nil {
+		t.Fatalf("first page has a prev cursor: %+v", first.Prev)
+	}
+
+	pages := []*domain.StocksPage{first}
+	for pages[len(pages)-1].Next != nil {
+		page, err := repo.GetStocks(ctx, pages[len(pages)-1].Next, 2, sort)
+		if err != nil {
+			t.Fatalf("GetStocks forward: %v", err)
+		}
+		pages = append(pages, page)
+	}
+
+	if len(pages) != 3 {
+		t.Fatalf("got %d pages going forward, want 3", len(pages))
+	}
+
+	for i := len(pages) - 1; i > 0; i-- {
+		if pages[i].Prev == nil {
+			t.Fatalf("page %d has no prev cursor", i)
+		}
+
+		back, err := repo.GetStocks(ctx, pages[i].Prev, 2, sort)
+		if err != nil {
+			t.Fatalf("GetStocks backward: %v", err)
+		}
+
+		assertTickers(t, back.Items, tickers(pages[i-1].Items)...)
+
+		if (back.Prev != nil) != (i-1 > 0) {
+			t.Fatalf("page %d reached backwards: prev cursor = %+v", i-1, back.Prev)
+		}
+		if back.Next == nil {
+			t.Fatalf("page %d reached backwards has no next cursor", i-1)
+		}
+	}
+}
+
+// testGetStocksSorts walks every sort two rows at a time, so each page
+// boundary goes through a cursor, including ties on the sort key and a
+// stock without a target change.
+func testGetStocksSorts(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	noChange := Stock("DDD", "Alpha", "initiated by", "Buy", 0, 10, base.Add(2*time.Hour))
+	noChange.TargetFrom = nil
+	noChange.TargetChange = nil
+	noChange.Company = "Beta Corp"
+
+	upsert(t, repo,
+		Stock("AAA", "Citi", "upgraded by", "Buy", 10, 15, base.Add(time.Hour)),
+		Stock("BBB", "Alpha", "upgraded by", "Buy", 10, 12, base),
+		Stock("CCC", "Citi", "downgraded by", "Sell", 10, 9, base.Add(3*time.Hour)),
+		noChange,
+		Stock("EEE", "Barclays", "upgraded by", "Buy", 10, 12, base.Add(time.Hour)),
+	)
+
+	cases := []struct {
+		sort domain.StocksSort
+		want string
+	}{
+		{domain.StocksSort{Field: domain.SortTicker, Desc: true}, "[EEE DDD CCC BBB AAA]"},
+		{domain.StocksSort{Field: domain.SortTime}, "[BBB AAA EEE DDD CCC]"},
+		{domain.StocksSort{Field: domain.SortTime, Desc: true}, "[CCC DDD EEE AAA BBB]"},
+		{domain.StocksSort{Field: domain.SortCompany}, "[AAA BBB DDD CCC EEE]"},
+		{domain.StocksSort{Field: domain.SortBrokerage}, "[BBB DDD EEE AAA CCC]"},
+		{domain.StocksSort{Field: domain.SortBrokerage, Desc: true}, "[CCC AAA EEE DDD BBB]"},
+		{domain.StocksSort{Field: domain.SortTargetChange}, "[CCC BBB EEE AAA DDD]"},
+		{domain.StocksSort{Field: domain.SortTargetChange, Desc: true}, "[AAA EEE BBB CCC DDD]"},
+	}
+
+	for _, c := range cases {
+		var page *domain.Cursor
+		var got []string
+
+		for i := 0; i < 5; i++ {
+			stocksPage, err := repo.GetStocks(ctx, page, 2, c.sort)
+			if err != nil {
+				t.Fatalf("GetStocks(%+v): %v", c.sort, err)
+			}
+
+			got = append(got, tickers(stocksPage.Items)...)
+
+			if stocksPage.Next == nil {
+				break
+			}
+			page = stocksPage.Next
+		}
+
+		if fmt.Sprint(got) != c.want {
+			t.Errorf("sort %s %s = %v, want %s", c.sort.Field, c.sort.Order(), got, c.want)
+		}
+	}
+}
+
+func testGetFilterStocks(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+	seedTargets(t, repo)
+
+	cases := []struct {
+		filter string
+		want   []string
+	}{
+		{"up", []string{"AAA", "BBA"}},
+		{"down", []string{"AAB", "BBB"}},
+		{"equal", []string{"AAC", "BBC"}},
+		{"", []string{"AAA", "AAB", "AAC", "AAD", "BBA", "BBB", "BBC"}},
+	}
+
+	for _, c := range cases {
+		filter := domain.StocksFilter{Target: c.filter}
+
+		page, err := repo.GetFilterStocks(ctx, nil, 10, filter, domain.DefaultStocksSort)
+		if err != nil {
+			t.Fatalf("GetFilterStocks(%s): %v", c.filter, err)
+		}
+
+		assertTickers(t, page.Items, c.want...)
+	}
+
+	up := domain.StocksFilter{Target: domain.StockFilterUp}
+	cursor := domain.Cursor{Sort: domain.DefaultStocksSort, Key: "AAA", Ticker: "AAA"}
+
+	page, err := repo.GetFilterStocks(ctx, &cursor, 10, up, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetFilterStocks after cursor: %v", err)
+	}
+
+	assertTickers(t, page.Items, "BBA")
+}
+
+// testGetFilterStocksFields checks every filter field alone and combined,
+// against both the listing and the stats counts.
+func testGetFilterStocksFields(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	noTarget := Stock("NOTG", "Citi", "target set by", "Buy", 0, 10, base)
+	noTarget.TargetFrom = nil
+	noTarget.TargetChange = nil
+
+	percent := Stock("PCT_", "Citi", "reiterated by", "Hold", 10, 10, base)
+	percent.Company = "100% Real_Estate"
+
+	upsert(t, repo,
+		Stock("AAPL", "Citi", "upgraded by", "Buy", 100, 120, base),
+		Stock("MSFT", "Jefferies", "downgraded by", "Sell", 300, 270, base.Add(24*time.Hour)),
+		Stock("NVDA", "jefferies", "upgraded by", "Strong-Buy", 500, 750, base.Add(48*time.Hour)),
+		Stock("ORCL", "Barclays", "initiated by", "buy", 50, 55, base.Add(72*time.Hour)),
+		noTarget,
+		percent,
+	)
+
+	float := func(v float64) *float64 { return &v }
+	at := func(d time.Duration) *time.Time { t := base.Add(d); return &t }
+
+	cases := []struct {
+		name   string
+		filter domain.StocksFilter
+		want   []string
+	}{
+		{"brokerage ignores case", domain.StocksFilter{Brokerage: "JEFFERIES"}, []string{"MSFT", "NVDA"}},
+		{"action", domain.StocksFilter{Action: "upgrade"}, []string{"AAPL", "NVDA"}},
+		{"rating ignores case", domain.StocksFilter{RatingTo: "Buy"}, []string{"AAPL", "NOTG", "ORCL"}},
+		{"canonical rating", domain.StocksFilter{Rating: domain.RatingBuy}, []string{"AAPL", "NOTG", "ORCL"}},
+		{"minimum canonical rating", domain.StocksFilter{MinRating: domain.RatingBuy}, []string{"AAPL", "NOTG", "NVDA", "ORCL"}},
+		{"company substring", domain.StocksFilter{Company: "vda"}, []string{"NVDA"}},
+		{"company wildcards are literal", domain.StocksFilter{Company: "0% Real_"}, []string{"PCT_"}},
+		{"ticker wildcards are literal", domain.StocksFilter{Ticker: "PC_"}, nil},
+		{"time range is inclusive", domain.StocksFilter{From: at(24 * time.Hour), To: at(48 * time.Hour)}, []string{"MSFT", "NVDA"}},
+		{"target range", domain.StocksFilter{MinTarget: float(55), MaxTarget: float(270)}, []string{"AAPL", "MSFT", "ORCL"}},
+		{"change range skips missing", domain.StocksFilter{MinChange: float(-10), MaxChange: float(10)}, []string{"MSFT", "ORCL", "PCT_"}},
+		{"combined", domain.StocksFilter{Target: domain.StockFilterUp, Action: "upgrade", MinChange: float(30)}, []string{"NVDA"}},
+	}
+
+	for _, c := range cases {
+		page, err := repo.GetFilterStocks(ctx, nil, 10, c.filter, domain.DefaultStocksSort)
+		if err != nil {
+			t.Fatalf("%s: GetFilterStocks: %v", c.name, err)
+		}
+
+		if fmt.Sprint(tickers(page.Items)) != fmt.Sprint(c.want) {
+			t.Errorf("%s: tickers = %v, want %v", c.name, tickers(page.Items), c.want)
+		}
+
+		stats, err := repo.GetStats(ctx, 1, c.filter)
+		if err != nil {
+			t.Fatalf("%s: GetStats: %v", c.name, err)
+		}
+
+		if stats.Pages != len(c.want) {
+			t.Errorf("%s: stats pages = %d, want %d", c.name, stats.Pages, len(c.want))
+		}
+	}
+}
+
+func testGetFilterStocksByTicker(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+	seedTargets(t, repo)
+
+	prefix := domain.StocksFilter{Ticker: "AA"}
+
+	page, err := repo.GetFilterStocks(ctx, nil, 2, prefix, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetFilterStocks: %v", err)
+	}
+
+	assertTickers(t, page.Items, "AAA", "AAB")
+
+	if page.Next == nil || page.Next.Ticker != "AAB" {
+		t.Fatalf("next cursor = %+v, want AAB", page.Next)
+	}
+
+	page, err = repo.GetFilterStocks(ctx, page.Next, 2, prefix, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetFilterStocks page 2: %v", err)
+	}
+
+	assertTickers(t, page.Items, "AAC", "AAD")
+
+	down := domain.StocksFilter{Ticker: "BB", Target: domain.StockFilterDown}
+
+	page, err = repo.GetFilterStocks(ctx, nil, 10, down, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetFilterStocks filtered: %v", err)
+	}
+
+	assertTickers(t, page.Items, "BBB")
+}
+
+// testGetFilterStocksMatchesEvents checks that filters look at every event
+// of a ticker, not only its newest, and that the listing shows the newest
+// matching one.
+func testGetFilterStocksMatchesEvents(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	upsert(t, repo,
+		Stock("AAPL", "Goldman Sachs", "upgraded by", "Buy", 100, 120, base),
+		Stock("AAPL", "Goldman Sachs", "reiterated by", "Buy", 120, 120, base.Add(time.Hour)),
+		Stock("AAPL", "Citi", "downgraded by", "Sell", 120, 90, base.Add(2*time.Hour)),
+		Stock("MSFT", "Citi", "upgraded by", "Buy", 300, 330, base),
+	)
+
+	page, err := repo.GetFilterStocks(ctx, nil, 10, domain.StocksFilter{Brokerage: "goldman sachs"}, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetFilterStocks: %v", err)
+	}
+
+	assertTickers(t, page.Items, "AAPL")
+
+	if got := page.Items[0]; got.Action != "reiterated by" {
+		t.Fatalf("AAPL shows %q, want the newest Goldman Sachs event", got.Action)
+	}
+
+	before := base.Add(90 * time.Minute)
+
+	page, err = repo.GetFilterStocks(ctx, nil, 10, domain.StocksFilter{To: &before, Target: domain.StockFilterUp}, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetFilterStocks time range: %v", err)
+	}
+
+	assertTickers(t, page.Items, "AAPL", "MSFT")
+
+	stats, err := repo.GetStats(ctx, 10, domain.StocksFilter{Brokerage: "Citi"})
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+
+	want := domain.StocksStats{AllStocks: 2, UpStocks: 1, DownStocks: 1, Pages: 1}
+	if *stats != want {
+		t.Fatalf("stats = %+v, want %+v", *stats, want)
+	}
+}
+
+func testGetStats(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+	seedTargets(t, repo)
+
+	stats, err := repo.GetStats(ctx, 2, domain.StocksFilter{})
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+
+	want := domain.StocksStats{AllStocks: 7, UpStocks: 2, DownStocks: 2, NoChange: 2, Pages: 4}
+	if *stats != want {
+		t.Fatalf("stats = %+v, want %+v", *stats, want)
+	}
+
+	stats, err = repo.GetStats(ctx, 2, domain.StocksFilter{Target: domain.StockFilterUp, Ticker: "AA"})
+	if err != nil {
+		t.Fatalf("GetStats filtered: %v", err)
+	}
+
+	want = domain.StocksStats{AllStocks: 4, UpStocks: 1, DownStocks: 1, NoChange: 1, Pages: 1}
+	if *stats != want {
+		t.Fatalf("stats = %+v, want %+v", *stats, want)
+	}
+}
+
+func testGetLatestRatings(t *testing.T, repo ports.StocksRepository) {
+	upsert(t, repo,
+		Stock("BBB", "Citi", "upgraded by", "Buy", 10, 20, base),
+		Stock("BBB", "Citi", "downgraded by", "Sell", 20, 10, base.Add(time.Hour)),
+		Stock("BBB", "UBS", "initiated by", "Hold", 10, 10, base),
+		Stock("AAA", "UBS", "upgraded by", "Strong-Buy", 10, 30, base),
+	)
+
+	ratings, err := repo.GetLatestRatings(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetLatestRatings: %v", err)
+	}
+
+	var got []string
+	for _, s := range *ratings {
+		got = append(got, s.Ticker+"/"+s.Brokerage+"/"+s.RatingTo)
+	}
+
+	want := []string{"AAA/UBS/Strong-Buy", "BBB/Citi/Sell", "BBB/UBS/Hold"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("latest ratings = %v, want %v", got, want)
+	}
+
+	ratings, err = repo.GetLatestRatings(context.Background(), "BBB")
+	if err != nil {
+		t.Fatalf("GetLatestRatings BBB: %v", err)
+	}
+
+	if len(*ratings) != 2 || (*ratings)[0].Brokerage != "Citi" || (*ratings)[1].Brokerage != "UBS" {
+		t.Fatalf("BBB ratings = %+v, want Citi and UBS", *ratings)
+	}
+}
+
+// testGetLatestRatingsBySlug checks that spellings of one brokerage count
+// as a single brokerage, rated by its newest event.
+func testGetLatestRatingsBySlug(t *testing.T, repo ports.StocksRepository) {
+	upsert(t, repo,
+		Stock("AAA", "Goldman Sachs", "upgraded by", "Buy", 10, 20, base),
+		Stock("AAA", "goldman sachs", "downgraded by", "Sell", 20, 10, base.Add(time.Hour)),
+		Stock("AAA", "UBS", "initiated by", "Hold", 10, 10, base),
+	)
+
+	ratings, err := repo.GetLatestRatings(context.Background(), "AAA")
+	if err != nil {
+		t.Fatalf("GetLatestRatings: %v", err)
+	}
+
+	var got []string
+	for _, s := range *ratings {
+		got = append(got, s.Brokerage+"/"+s.RatingTo)
+	}
+
+	want := []string{"goldman sachs/Sell", "UBS/Hold"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("latest ratings = %v, want %v", got, want)
+	}
+}
+
+// testSearchBrokerageOnce checks that spellings of one brokerage make a
+// single suggestion, named after the newest spelling.
+func testSearchBrokerageOnce(t *testing.T, repo ports.StocksRepository) {
+	upsert(t, repo,
+		Stock("AAPL", "goldman sachs", "upgraded by", "Buy", 10, 12, base),
+		Stock("MSFT", "Goldman Sachs", "upgraded by", "Buy", 10, 12, base.Add(time.Hour)),
+	)
+
+	suggestions, err := repo.Search(context.Background(), "goldman", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	var brokerages []string
+	for _, s := range suggestions {
+		if s.Kind == domain.SuggestionBrokerage {
+			brokerages = append(brokerages, s.Brokerage)
+		}
+	}
+
+	if len(brokerages) != 1 || brokerages[0] != "Goldman Sachs" {
+		t.Fatalf("brokerage suggestions = %q, want one Goldman Sachs", brokerages)
+	}
+}
+
+func testGetStockHistoryUnknown(t *testing.T, repo ports.StocksRepository) {
+	history, err := repo.GetStockHistory(context.Background(), "NOPE")
+	if err != nil {
+		t.Fatalf("GetStockHistory: %v", err)
+	}
+
+	if history == nil || len(*history) != 0 {
+		t.Fatalf("history = %v, want empty", history)
+	}
+}
+
+func testSearchRanks(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	company := func(stock domain.Stock, name string) domain.Stock {
+		stock.Company = name
+		return stock
+	}
+
+	upsert(t, repo,
+		company(Stock("AAPL", "Citi", "upgraded by", "Buy", 10, 12, base), "Apple Inc."),
+		company(Stock("AAP", "Citi", "upgraded by", "Buy", 10, 12, base), "Advance Auto Parts"),
+		company(Stock("APLE", "Citi", "upgraded by", "Buy", 10, 12, base), "Apple Hospitality REIT"),
+		company(Stock("APL2", "Citi", "upgraded by", "Buy", 10, 12, base), "Appl"),
+		company(Stock("MSFT", "Apple Capital", "upgraded by", "Buy", 10, 12, base), "Microsoft"),
+	)
+
+	describe := func(suggestions []domain.SearchSuggestion) []string {
+		out := make([]string, 0, len(suggestions))
+		for _, s := range suggestions {
+			out = append(out, s.Ticker+s.Brokerage+":"+s.Match)
+		}
+		return out
+	}
+
+	cases := []struct {
+		query string
+		limit int
+		want  string
+	}{
+		{"aap", 10, "[AAP:exact_ticker AAPL:ticker_prefix]"},
+		{"apple", 10, "[AAPL:company APLE:company Apple Capital:brokerage APL2:company]"},
+		{"apple", 2, "[AAPL:company APLE:company]"},
+		{"zzzz", 10, "[]"},
+	}
+
+	for _, c := range cases {
+		suggestions, err := repo.Search(ctx, c.query, c.limit)
+		if err != nil {
+			t.Fatalf("Search(%q): %v", c.query, err)
+		}
+
+		if got := fmt.Sprint(describe(suggestions)); got != c.want {
+			t.Errorf("Search(%q, %d) = %s, want %s", c.query, c.limit, got, c.want)
+		}
+	}
+}
+
+func testGetBrokerages(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	upsert(t, repo,
+		Stock("AAA", "Goldman Sachs", "upgraded by", "Buy", 10, 12, base),
+		Stock("BBB", "Goldman Sachs", "upgraded by", "Buy", 10, 14, base.Add(time.Hour)),
+		Stock("AAA", "goldman sachs", "downgraded by", "Sell", 12, 9, base.Add(2*time.Hour)),
+	)
+	upsert(t, repo,
+		// An older batch keeps the newest spelling as the name.
+		Stock("CCC", "GOLDMAN SACHS", "initiated by", "Hold", 10, 10, base.Add(-time.Hour)),
+		Stock("AAA", "UBS", "initiated by", "Hold", 10, 10, base),
+	)
+
+	brokerages, err := repo.GetBrokerages(ctx)
+	if err != nil {
+		t.Fatalf("GetBrokerages: %v", err)
+	}
+
+	if len(brokerages) != 2 {
+		t.Fatalf("brokerages = %+v, want goldman-sachs and ubs", brokerages)
+	}
+
+	gs := brokerages[0]
+	if gs.Slug != "goldman-sachs" || gs.Name != "goldman sachs" {
+		t.Fatalf("first brokerage = %s %q, want goldman-sachs named \"goldman sachs\"", gs.Slug, gs.Name)
+	}
+	if !gs.FirstSeenAt.Equal(base.Add(-time.Hour)) || !gs.LastSeenAt.Equal(base.Add(2*time.Hour)) {
+		t.Fatalf("seen from %s to %s", gs.FirstSeenAt, gs.LastSeenAt)
+	}
+	if gs.Actions != 4 || gs.Tickers != 3 || gs.Upgrades != 2 || gs.Downgrades != 1 {
+		t.Fatalf("goldman-sachs counts = %+v", gs)
+	}
+	if gs.UpgradeDowngradeRatio == nil || *gs.UpgradeDowngradeRatio != 2 {
+		t.Fatalf("upgrade/downgrade ratio = %v, want 2", gs.UpgradeDowngradeRatio)
+	}
+	// (20 + 40 - 25 + 0) / 4
+	if gs.AvgTargetChange == nil || math.Abs(*gs.AvgTargetChange-8.75) > 1e-9 {
+		t.Fatalf("average target change = %v, want 8.75", gs.AvgTargetChange)
+	}
+
+	if ubs := brokerages[1]; ubs.Slug != "ubs" || ubs.UpgradeDowngradeRatio != nil {
+		t.Fatalf("second brokerage = %+v, want ubs without a ratio", ubs)
+	}
+
+	brokerage, err := repo.GetBrokerage(ctx, "goldman-sachs")
+	if err != nil || brokerage == nil || brokerage.Name != "goldman sachs" {
+		t.Fatalf("GetBrokerage = %+v, %v", brokerage, err)
+	}
+
+	brokerage, err = repo.GetBrokerage(ctx, "nope")
+	if err != nil || brokerage != nil {
+		t.Fatalf("GetBrokerage unknown = %+v, %v; want nil", brokerage, err)
+	}
+}
+
+func testGetBrokerageActions(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	upsert(t, repo,
+		Stock("AAA", "Citi", "upgraded by", "Buy", 10, 12, base),
+		Stock("AAA", "Citi", "downgraded by", "Sell", 12, 9, base.Add(time.Hour)),
+		// Newer, but by another brokerage.
+		Stock("AAA", "UBS", "upgraded by", "Buy", 10, 20, base.Add(2*time.Hour)),
+		Stock("BBB", "Citi", "upgraded by", "Buy", 10, 15, base),
+		Stock("CCC", "Citi", "initiated by", "Hold", 10, 10, base),
+		Stock("DDD", "UBS", "upgraded by", "Buy", 10, 15, base),
+	)
+
+	page, err := repo.GetBrokerageActions(ctx, "citi", nil, 2, domain.StocksFilter{}, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetBrokerageActions: %v", err)
+	}
+
+	assertTickers(t, page.Items, "AAA", "BBB")
+	if page.Items[0].Action != "downgraded by" || page.Next == nil {
+		t.Fatalf("first page = %+v, want the latest Citi action on AAA and more to come", page)
+	}
+
+	page, err = repo.GetBrokerageActions(ctx, "citi", page.Next, 2, domain.StocksFilter{}, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetBrokerageActions next: %v", err)
+	}
+
+	assertTickers(t, page.Items, "CCC")
+
+	page, err = repo.GetBrokerageActions(ctx, "citi", nil, 10, domain.StocksFilter{Action: "upgrade"}, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetBrokerageActions filtered: %v", err)
+	}
+
+	// AAA's upgrade is not Citi's latest action on it, but still matches.
+	assertTickers(t, page.Items, "AAA", "BBB")
+	if page.Items[0].Action != "upgraded by" {
+		t.Fatalf("AAA shows %q, want Citi's upgrade", page.Items[0].Action)
+	}
+}
+
+func testGetCompany(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	renamed := Stock("AAA", "UBS", "upgraded by", "Buy", 10, 12, base.Add(time.Hour))
+	renamed.Company = "AAA Holdings"
+
+	upsert(t, repo, Stock("AAA", "Citi", "upgraded by", "Buy", 10, 12, base), renamed)
+	upsert(t, repo, Stock("AAA", "Citi", "initiated by", "Buy", 10, 12, base.Add(-time.Hour)))
+
+	company, err := repo.GetCompany(ctx, "AAA")
+	if err != nil || company == nil {
+		t.Fatalf("GetCompany = %+v, %v", company, err)
+	}
+
+	if company.Name != "AAA Holdings" || !company.FirstSeenAt.Equal(base.Add(-time.Hour)) || !company.LastSeenAt.Equal(base.Add(time.Hour)) {
+		t.Fatalf("company = %+v, want the newest name and the full seen range", company)
+	}
+
+	company, err = repo.GetCompany(ctx, "NOPE")
+	if err != nil || company != nil {
+		t.Fatalf("GetCompany unknown = %+v, %v; want nil", company, err)
+	}
+}
+
+func testGetTimeseries(t *testing.T, repo ports.StocksRepository) {
+	ctx := context.Background()
+
+	// base is Monday 2025-03-10 14:00 UTC.
+	upsert(t, repo,
+		Stock("AAA", "Citi", "upgraded by", "Buy", 10, 12, base),
+		Stock("BBB", "Citi", "downgraded by", "Sell", 10, 8, base.Add(2*time.Hour)),
+		Stock("CCC", "UBS", "initiated by", "Hold", 10, 10, base.Add(24*time.Hour)),
+		Stock("AAA", "UBS", "upgraded by", "Buy", 10, 11, base.Add(7*24*time.Hour)),
+		Stock("DDD", "UBS", "reiterated by", "Buy", 10, 10, base.Add(-24*time.Hour)),
+	)
+
+	format := func(points []domain.TimeseriesPoint) []string {
+		var out []string
+		for _, p := range points {
+			change := "-"
+			if p.AvgTargetChange != nil {
+				change = fmt.Sprintf("%.1f", *p.AvgTargetChange)
+			}
+			out = append(out, fmt.Sprintf("%s %s %d/%d/%d/%d %s", p.Key, p.Bucket.Format(time.DateOnly), p.Actions, p.Upgrades, p.Downgrades, p.Initiations, change))
+		}
+		return out
+	}
+
+	check := func(name string, query domain.TimeseriesQuery, want ...string) {
+		t.Helper()
+
+		points, err := repo.GetTimeseries(ctx, query)
+		if err != nil {
+			t.Fatalf("%s: GetTimeseries: %v", name, err)
+		}
+
+		if got := format(points); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s: points = %q, want %q", name, got, want)
+		}
+	}
+
+	from, to := base, base.Add(24*time.Hour)
+
+	check("day", domain.TimeseriesQuery{Bucket: domain.BucketDay, From: &from, To: &to},
+		" 2025-03-10 2/1/1/0 0.0",
+		" 2025-03-11 1/0/0/1 0.0",
+	)
+	check("week", domain.TimeseriesQuery{Bucket: domain.BucketWeek},
+		" 2025-03-03 1/0/0/0 0.0",
+		" 2025-03-10 3/1/1/1 0.0",
+		" 2025-03-17 1/1/0/0 10.0",
+	)
+	check("month by brokerage", domain.TimeseriesQuery{Bucket: domain.BucketMonth, GroupBy: domain.GroupByBrokerage},
+		"citi 2025-03-01 2/1/1/0 0.0",
+		"ubs 2025-03-01 3/1/0/1 3.3",
+	)
+}
@@ -0,0 +1,159 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cron is a standard five field expression: minute, hour, day of month,
+// month and day of week. Each field accepts *, lists, ranges and steps
+// ("*/15", "1-5", "0,30"). Sunday is 0 or 7.
+type cron struct {
+	minute     []bool
+	hour       []bool
+	dayOfMonth []bool
+	month      []bool
+	dayOfWeek  []bool
+
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseCron parses a five field cron expression evaluated in local time.
+func ParseCron(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron: expected 5 fields in %q, got %d", expr, len(fields))
+	}
+
+	sets := make([][]bool, len(fields))
+
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("cron: %s: %w", cronFields[i].name, err)
+		}
+		sets[i] = set
+	}
+
+	// Both 0 and 7 are Sunday.
+	sets[4][0] = sets[4][0] || sets[4][7]
+
+	return &cron{
+		minute:        sets[0],
+		hour:          sets[1],
+		dayOfMonth:    sets[2],
+		month:         sets[3],
+		dayOfWeek:     sets[4],
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseCronField(field string, min, max int) ([]bool, error) {
+	set := make([]bool, max+1)
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+
+		if base, stepText, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepText)
+			}
+			step = n
+			part = base
+		}
+
+		from, to := min, max
+
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			lo, hi, _ := strings.Cut(part, "-")
+			var err error
+			if from, err = strconv.Atoi(lo); err != nil {
+				return nil, fmt.Errorf("invalid value %q", lo)
+			}
+			if to, err = strconv.Atoi(hi); err != nil {
+				return nil, fmt.Errorf("invalid value %q", hi)
+			}
+		default:
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", part)
+			}
+			from, to = n, n
+			if step > 1 {
+				to = max
+			}
+		}
+
+		if from < min || to > max || from > to {
+			return nil, fmt.Errorf("value out of range [%d-%d] in %q", min, max, field)
+		}
+
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+
+	return set, nil
+}
+
+// Next walks forward minute by minute, skipping whole days and hours that
+// cannot match. Expressions that never match (such as "0 0 31 2 *") give up
+// after five years and return the zero time.
+func (c *cron) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !c.month[int(t.Month())] || !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchesDay follows the usual cron rule: when both day fields are
+// restricted, a day matching either one is enough. A field starting with
+// "*", steps included, does not count as restricted.
+func (c *cron) matchesDay(t time.Time) bool {
+	dom := c.dayOfMonth[t.Day()]
+	dow := c.dayOfWeek[int(t.Weekday())]
+
+	if c.anyDayOfMonth || c.anyDayOfWeek {
+		return dom && dow
+	}
+
+	return dom || dow
+}
@@ -0,0 +1,83 @@
+package schedule_test
+
+import (
+	"backend/internal/schedule"
+	"testing"
+	"time"
+)
+
+// Monday 10 March 2025, 14:07.
+var base = time.Date(2025, time.March, 10, 14, 7, 30, 0, time.UTC)
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, time.March, 10, 14, 8, 0, 0, time.UTC)},
+		{"30 * * * *", time.Date(2025, time.March, 10, 14, 30, 0, 0, time.UTC)},
+		{"5 * * * *", time.Date(2025, time.March, 10, 15, 5, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, time.March, 10, 14, 15, 0, 0, time.UTC)},
+		{"10/20 * * * *", time.Date(2025, time.March, 10, 14, 10, 0, 0, time.UTC)},
+		{"0,45 * * * *", time.Date(2025, time.March, 10, 14, 45, 0, 0, time.UTC)},
+		{"0 9-17 * * *", time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC)},
+		{"0 0-12/6 * * *", time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Day of week.
+		{"0 0 * * 1-5", time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 6-7", time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * */3", time.Date(2025, time.March, 12, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either one matches.
+		{"0 0 15 * 5", time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)},
+		// A stepped "*" leaves the day of week unrestricted.
+		{"0 0 */10 * *", time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		sched, err := schedule.ParseCron(tt.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q): %v", tt.expr, err)
+			continue
+		}
+
+		if got := sched.Next(base); !got.Equal(tt.want) {
+			t.Errorf("%q: Next = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestCronNeverMatches(t *testing.T) {
+	sched, err := schedule.ParseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("ParseCron: %v", err)
+	}
+
+	if got := sched.Next(base); !got.IsZero() {
+		t.Fatalf("Next = %s, want the zero time", got)
+	}
+}
+
+func TestParseCronRejects(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1-x * * * *",
+	} {
+		if _, err := schedule.ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want an error", expr)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package schedule
+
+import "time"
+
+// Schedule tells when the next run after a given time should happen.
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+type interval struct {
+	every time.Duration
+}
+
+// Every runs at a fixed interval, counted from the previous run.
+func Every(every time.Duration) Schedule {
+	return interval{every: every}
+}
+
+func (i interval) Next(after time.Time) time.Time {
+	return after.Add(i.every)
+}
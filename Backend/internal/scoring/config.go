@@ -0,0 +1,179 @@
+package scoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Config tunes the engine. It is read from a JSON file, see
+// config/scoring.json for an example.
+type Config struct {
+	DefaultStrategy string                    `json:"default_strategy"`
+	Strategies      map[string]StrategyConfig `json:"strategies"`
+
+	// UpliftCapPercent is the target change that earns the full uplift
+	// score; larger changes are capped.
+	UpliftCapPercent float64 `json:"uplift_cap_percent"`
+	// RecencyHalfLife is how old an action is when its recency score has
+	// halved, counted back from the newest action in the data.
+	RecencyHalfLife Duration `json:"recency_half_life"`
+	// AgreementSaturation is the number of agreeing brokerages that earns
+	// the full agreement score.
+	AgreementSaturation int `json:"agreement_saturation"`
+	// BrokerageReputation scores brokerages in [0, 1]; unlisted ones get
+	// DefaultReputation.
+	BrokerageReputation map[string]float64 `json:"brokerage_reputation"`
+	DefaultReputation   float64            `json:"default_reputation"`
+}
+
+type StrategyConfig struct {
+	// Weights maps factor names to their weight. Missing factors weigh 0.
+	Weights map[string]float64 `json:"weights"`
+	// MinRatingStrength drops stocks whose latest rating is weaker.
+	MinRatingStrength float64 `json:"min_rating_strength"`
+}
+
+// Duration reads a time.Duration from a string such as "336h".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+// DefaultConfig is used when no config file is given.
+func DefaultConfig() Config {
+	return Config{
+		DefaultStrategy: "balanced",
+		Strategies: map[string]StrategyConfig{
+			"balanced": {
+				Weights: map[string]float64{
+					FactorRatingStrength:      3,
+					FactorTargetUplift:        3,
+					FactorRecency:             1.5,
+					FactorBrokerageReputation: 1,
+					FactorAgreement:           1.5,
+				},
+				MinRatingStrength: 0.75,
+			},
+			"momentum": {
+				Weights: map[string]float64{
+					FactorTargetUplift:   4,
+					FactorRecency:        4,
+					FactorRatingStrength: 2,
+				},
+				MinRatingStrength: 0.75,
+			},
+			"consensus": {
+				Weights: map[string]float64{
+					FactorAgreement:           5,
+					FactorRatingStrength:      3,
+					FactorBrokerageReputation: 2,
+				},
+				MinRatingStrength: 0.75,
+			},
+		},
+		UpliftCapPercent:    50,
+		RecencyHalfLife:     Duration(14 * 24 * time.Hour),
+		AgreementSaturation: 3,
+		BrokerageReputation: map[string]float64{},
+		DefaultReputation:   0.5,
+	}
+}
+
+// LoadConfig reads path over DefaultConfig, so a file only needs the
+// settings it changes. An empty path returns the defaults.
+func LoadConfig(path string) (Config, error) {
+	config := DefaultConfig()
+
+	if path == "" {
+		return config, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("scoring: read config: %w", err)
+	}
+
+	// Strategies from the file replace the defaults instead of merging
+	// weight by weight.
+	config.Strategies = nil
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Config{}, fmt.Errorf("scoring: parse %s: %w", path, err)
+	}
+
+	if config.Strategies == nil {
+		config.Strategies = DefaultConfig().Strategies
+	}
+
+	if err := config.lowerNames(); err != nil {
+		return Config{}, fmt.Errorf("scoring: %s: %w", path, err)
+	}
+
+	if err := config.validate(); err != nil {
+		return Config{}, fmt.Errorf("scoring: %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// lowerNames lower-cases the strategy names, as the strategy query
+// parameter is, and rejects names that only differ by case.
+func (c *Config) lowerNames() error {
+	strategies := make(map[string]StrategyConfig, len(c.Strategies))
+
+	for name, strategy := range c.Strategies {
+		lower := strings.ToLower(name)
+		if _, ok := strategies[lower]; ok {
+			return fmt.Errorf("strategy %q is defined twice with different cases", lower)
+		}
+		strategies[lower] = strategy
+	}
+
+	c.Strategies = strategies
+	c.DefaultStrategy = strings.ToLower(c.DefaultStrategy)
+
+	return nil
+}
+
+func (c Config) validate() error {
+	if _, ok := c.Strategies[c.DefaultStrategy]; !ok {
+		return fmt.Errorf("default_strategy %q is not defined", c.DefaultStrategy)
+	}
+
+	for name, strategy := range c.Strategies {
+		total := 0.0
+		for factor, weight := range strategy.Weights {
+			if _, ok := factors[factor]; !ok {
+				return fmt.Errorf("strategy %q: unknown factor %q", name, factor)
+			}
+			if weight < 0 {
+				return fmt.Errorf("strategy %q: factor %q has a negative weight", name, factor)
+			}
+			total += weight
+		}
+		if total == 0 {
+			return fmt.Errorf("strategy %q has no weighted factor", name)
+		}
+	}
+
+	if c.UpliftCapPercent <= 0 || c.RecencyHalfLife <= 0 || c.AgreementSaturation <= 0 {
+		return fmt.Errorf("uplift_cap_percent, recency_half_life and agreement_saturation must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+// Package scoring ranks stocks for the top-stocks listing with weighted,
+// configurable factors.
+package scoring
+
+import (
+	"backend/internal/domain"
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Candidate is a ticker up for ranking: its latest rating event and the
+// latest rating of every brokerage covering it.
+type Candidate struct {
+	Latest  domain.Stock
+	Ratings []domain.Stock
+}
+
+type strategy struct {
+	weights     []weightedFactor
+	total       float64
+	minStrength float64
+}
+
+type weightedFactor struct {
+	factor Factor
+	weight float64
+}
+
+type Engine struct {
+	defaultStrategy string
+	strategies      map[string]strategy
+}
+
+// NewEngine builds the strategies of a config that passed LoadConfig's
+// validation; unknown factors are ignored. Strategy names are lower-cased
+// like LoadConfig does.
+func NewEngine(config Config) *Engine {
+	engine := &Engine{
+		defaultStrategy: strings.ToLower(config.DefaultStrategy),
+		strategies:      make(map[string]strategy, len(config.Strategies)),
+	}
+
+	for name, sc := range config.Strategies {
+		s := strategy{minStrength: sc.MinRatingStrength}
+
+		// Sorted so the breakdown lists factors in a stable order.
+		names := make([]string, 0, len(sc.Weights))
+		for factor, weight := range sc.Weights {
+			if _, ok := factors[factor]; ok && weight > 0 {
+				names = append(names, factor)
+			}
+		}
+		slices.Sort(names)
+
+		for _, factor := range names {
+			weight := sc.Weights[factor]
+			s.weights = append(s.weights, weightedFactor{factors[factor](config), weight})
+			s.total += weight
+		}
+
+		engine.strategies[strings.ToLower(name)] = s
+	}
+
+	return engine
+}
+
+// Strategies returns the strategy names, sorted.
+func (e *Engine) Strategies() []string {
+	names := make([]string, 0, len(e.strategies))
+	for name := range e.strategies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (e *Engine) DefaultStrategy() string {
+	return e.defaultStrategy
+}
+
+// Strategy returns the name of the strategy name stands for, the default
+// one when it is empty, or an InvalidArgument error when there is none.
+func (e *Engine) Strategy(name string) (string, error) {
+	if name == "" {
+		name = e.defaultStrategy
+	}
+
+	if _, ok := e.strategies[name]; !ok {
+		return "", domain.InvalidArgument("Invalid query parameters", map[string]string{
+			"strategy": "must be one of " + strings.Join(e.Strategies(), ", "),
+		})
+	}
+
+	return name, nil
+}
+
+// Rank scores the tickers in ratings, the latest rating of every
+// (ticker, brokerage) pair, and returns the best limit of them. An empty
+// strategy uses the default one.
+//
+// Recency is measured from the newest rating in ratings rather than the
+// wall clock, so rankings do not decay between syncs.
+func (e *Engine) Rank(strategyName string, ratings []domain.Stock, limit int) ([]domain.ScoredStock, error) {
+	strategyName, err := e.Strategy(strategyName)
+	if err != nil {
+		return nil, err
+	}
+
+	s := e.strategies[strategyName]
+
+	candidates := group(ratings)
+
+	var now time.Time
+	for _, c := range candidates {
+		if c.Latest.Time.After(now) {
+			now = c.Latest.Time
+		}
+	}
+
+	scored := make([]domain.ScoredStock, 0, len(candidates))
+
+	for _, c := range candidates {
+		if c.Latest.TargetFrom == nil || *c.Latest.TargetFrom <= 0 {
+			continue
+		}
+		if strength, _ := RatingStrength(c.Latest.RatingToCanonical); strength < s.minStrength {
+			continue
+		}
+
+		scored = append(scored, s.score(strategyName, c, now))
+	}
+
+	slices.SortStableFunc(scored, func(a, b domain.ScoredStock) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), strings.Compare(a.Ticker, b.Ticker))
+	})
+
+	if limit >= 0 && len(scored) > limit {
+		scored = scored[:limit]
+	}
+
+	return scored, nil
+}
+
+func (s strategy) score(name string, c Candidate, now time.Time) domain.ScoredStock {
+	result := domain.ScoredStock{
+		Stock:    c.Latest,
+		Strategy: name,
+		Factors:  make([]domain.FactorScore, 0, len(s.weights)),
+	}
+
+	for _, wf := range s.weights {
+		value := wf.factor.Score(c, now)
+		contribution := 100 * wf.weight * value / s.total
+
+		result.Score += contribution
+		result.Factors = append(result.Factors, domain.FactorScore{
+			Name:         wf.factor.Name(),
+			Value:        round(value, 4),
+			Weight:       wf.weight,
+			Contribution: round(contribution, 2),
+		})
+	}
+
+	result.Score = round(result.Score, 2)
+
+	return result
+}
+
+// group collects the ratings of each ticker. Latest is the newest rating,
+// with ties broken like the listings' latest event.
+func group(ratings []domain.Stock) []Candidate {
+	var candidates []Candidate
+	index := map[string]int{}
+
+	for _, rating := range ratings {
+		i, ok := index[rating.Ticker]
+		if !ok {
+			i = len(candidates)
+			index[rating.Ticker] = i
+			candidates = append(candidates, Candidate{Latest: rating})
+		}
+
+		c := &candidates[i]
+		c.Ratings = append(c.Ratings, rating)
+		if newer(rating, c.Latest) {
+			c.Latest = rating
+		}
+	}
+
+	return candidates
+}
+
+func newer(a, b domain.Stock) bool {
+	if !a.Time.Equal(b.Time) {
+		return a.Time.After(b.Time)
+	}
+	if a.Brokerage != b.Brokerage {
+		return a.Brokerage > b.Brokerage
+	}
+	return a.Action > b.Action
+}
+
+func round(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
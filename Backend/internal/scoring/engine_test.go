@@ -0,0 +1,118 @@
+package scoring_test
+
+import (
+	"backend/internal/domain"
+	"backend/internal/repository/repotest"
+	"backend/internal/scoring"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var base = time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+
+func TestRankOrdersByScore(t *testing.T) {
+	engine := scoring.NewEngine(scoring.DefaultConfig())
+
+	ratings := []domain.Stock{
+		repotest.Stock("AGRD", "Citi", "upgraded by", "Buy", 10, 14, base),
+		repotest.Stock("AGRD", "UBS", "upgraded by", "Buy", 10, 14, base),
+		repotest.Stock("AGRD", "Barclays", "upgraded by", "Buy", 10, 14, base),
+		repotest.Stock("LONE", "Citi", "upgraded by", "Buy", 10, 14, base),
+		repotest.Stock("OLD", "Citi", "upgraded by", "Buy", 10, 14, base.Add(-60*24*time.Hour)),
+		repotest.Stock("SELL", "Citi", "downgraded by", "Sell", 10, 20, base),
+		repotest.Stock("ZERO", "Citi", "initiated by", "Strong-Buy", 0, 20, base),
+	}
+
+	top, err := engine.Rank("", ratings, 10)
+	if err != nil {
+		t.Fatalf("Rank: %v", err)
+	}
+
+	var got []string
+	for _, s := range top {
+		got = append(got, s.Ticker)
+	}
+
+	if want := []string{"AGRD", "LONE", "OLD"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("ranked %v, want %v", got, want)
+	}
+
+	total := 0.0
+	for _, f := range top[0].Factors {
+		total += f.Contribution
+	}
+	if diff := total - top[0].Score; diff > 0.05 || diff < -0.05 {
+		t.Fatalf("factor contributions add up to %v, score is %v", total, top[0].Score)
+	}
+
+	top, err = engine.Rank("balanced", ratings, 1)
+	if err != nil || len(top) != 1 {
+		t.Fatalf("Rank limited = %d stocks, %v; want 1", len(top), err)
+	}
+}
+
+func TestRankRejectsUnknownStrategy(t *testing.T) {
+	engine := scoring.NewEngine(scoring.DefaultConfig())
+
+	_, err := engine.Rank("nope", nil, 5)
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("err = %v, want an invalid argument", err)
+	}
+}
+
+func TestLoadConfigExample(t *testing.T) {
+	config, err := scoring.LoadConfig("../../config/scoring.json")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	engine := scoring.NewEngine(config)
+	if got := fmt.Sprint(engine.Strategies()); got != "[balanced consensus momentum]" {
+		t.Fatalf("strategies = %s", got)
+	}
+}
+
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "scoring.json")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigLowersStrategyNames(t *testing.T) {
+	config, err := scoring.LoadConfig(writeConfig(t, `{
+		"default_strategy": "Momentum",
+		"strategies": {"Momentum": {"weights": {"target_uplift": 1}}}
+	}`))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	engine := scoring.NewEngine(config)
+	if name, err := engine.Strategy("momentum"); err != nil || name != "momentum" {
+		t.Fatalf("Strategy(momentum) = %q, %v", name, err)
+	}
+	if name, err := engine.Strategy(""); err != nil || name != "momentum" {
+		t.Fatalf("default strategy = %q, %v", name, err)
+	}
+}
+
+func TestLoadConfigRejectsNamesDifferingByCase(t *testing.T) {
+	_, err := scoring.LoadConfig(writeConfig(t, `{
+		"default_strategy": "fast",
+		"strategies": {
+			"fast": {"weights": {"target_uplift": 1}},
+			"Fast": {"weights": {"target_uplift": 2}}
+		}
+	}`))
+	if err == nil {
+		t.Fatal("LoadConfig accepted two strategies differing only by case")
+	}
+}
@@ -0,0 +1,133 @@
+package scoring
+
+import (
+	"backend/internal/domain"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	FactorRatingStrength      = "rating_strength"
+	FactorTargetUplift        = "target_uplift"
+	FactorRecency             = "recency"
+	FactorBrokerageReputation = "brokerage_reputation"
+	FactorAgreement           = "agreement"
+)
+
+// Factor scores one aspect of a candidate in [0, 1]. now is the time the
+// ranking is computed for.
+type Factor interface {
+	Name() string
+	Score(candidate Candidate, now time.Time) float64
+}
+
+// factors builds every known factor from the config, keyed by name.
+var factors = map[string]func(Config) Factor{
+	FactorRatingStrength:      func(Config) Factor { return ratingStrength{} },
+	FactorTargetUplift:        func(c Config) Factor { return targetUplift{cap: c.UpliftCapPercent} },
+	FactorRecency:             func(c Config) Factor { return recency{halfLife: time.Duration(c.RecencyHalfLife)} },
+	FactorBrokerageReputation: newBrokerageReputation,
+	FactorAgreement:           func(c Config) Factor { return agreement{saturation: c.AgreementSaturation} },
+}
+
+// bullish is the strength from which a rating counts as a buy.
+const bullish = 0.75
+
+// RatingStrength places a canonical rating on a 0 (strong sell) to 1
+// (strong buy) scale and reports whether it is known.
+func RatingStrength(canonical *string) (float64, bool) {
+	if canonical == nil {
+		return 0, false
+	}
+
+	level := domain.RatingLevel(*canonical)
+	if level == 0 {
+		return 0, false
+	}
+
+	return float64(level-1) / float64(len(domain.CanonicalRatings)-1), true
+}
+
+type ratingStrength struct{}
+
+func (ratingStrength) Name() string { return FactorRatingStrength }
+
+func (ratingStrength) Score(c Candidate, _ time.Time) float64 {
+	strength, _ := RatingStrength(c.Latest.RatingToCanonical)
+	return strength
+}
+
+// targetUplift scores the latest target change, reaching 1 at cap percent.
+// Cuts score 0.
+type targetUplift struct {
+	cap float64
+}
+
+func (targetUplift) Name() string { return FactorTargetUplift }
+
+func (f targetUplift) Score(c Candidate, _ time.Time) float64 {
+	if c.Latest.TargetChange == nil {
+		return 0
+	}
+	return clamp(*c.Latest.TargetChange / f.cap)
+}
+
+// recency halves the score of an action every halfLife.
+type recency struct {
+	halfLife time.Duration
+}
+
+func (recency) Name() string { return FactorRecency }
+
+func (f recency) Score(c Candidate, now time.Time) float64 {
+	age := now.Sub(c.Latest.Time)
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(f.halfLife))
+}
+
+type brokerageReputation struct {
+	scores   map[string]float64
+	fallback float64
+}
+
+func newBrokerageReputation(c Config) Factor {
+	scores := make(map[string]float64, len(c.BrokerageReputation))
+	for name, score := range c.BrokerageReputation {
+		scores[strings.ToLower(name)] = clamp(score)
+	}
+	return brokerageReputation{scores: scores, fallback: clamp(c.DefaultReputation)}
+}
+
+func (brokerageReputation) Name() string { return FactorBrokerageReputation }
+
+func (f brokerageReputation) Score(c Candidate, _ time.Time) float64 {
+	if score, ok := f.scores[strings.ToLower(c.Latest.Brokerage)]; ok {
+		return score
+	}
+	return f.fallback
+}
+
+// agreement counts the brokerages whose latest rating is a buy, reaching 1
+// at saturation brokerages.
+type agreement struct {
+	saturation int
+}
+
+func (agreement) Name() string { return FactorAgreement }
+
+func (f agreement) Score(c Candidate, _ time.Time) float64 {
+	agreeing := 0
+	for _, rating := range c.Ratings {
+		if strength, _ := RatingStrength(rating.RatingToCanonical); strength >= bullish {
+			agreeing++
+		}
+	}
+	return clamp(float64(agreeing) / float64(f.saturation))
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
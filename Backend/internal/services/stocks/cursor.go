@@ -0,0 +1,32 @@
+package stocks
+
+import (
+	"backend/internal/cursor"
+	"backend/internal/domain"
+)
+
+func scopeOf(sort domain.StocksSort, filter domain.StocksFilter) cursor.Scope {
+	return cursor.Scope{Sort: sort, Filter: filter.String()}
+}
+
+// decodePage turns the client's cursor back into a repository position.
+func (s *Service) decodePage(page *string, scope cursor.Scope) (*domain.Cursor, error) {
+	if page == nil {
+		return nil, nil
+	}
+
+	return s.Cursors.Decode(*page, scope)
+}
+
+// encodePages fills NextPage and PrevPage with the signed form of the
+// repository cursors.
+func (s *Service) encodePages(stocksPage *domain.StocksPage, scope cursor.Scope) *domain.StocksPage {
+	if stocksPage.Next != nil {
+		stocksPage.NextPage = s.Cursors.Encode(*stocksPage.Next, scope)
+	}
+	if stocksPage.Prev != nil {
+		stocksPage.PrevPage = s.Cursors.Encode(*stocksPage.Prev, scope)
+	}
+
+	return stocksPage
+}
@@ -0,0 +1,56 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+// GetBrokerageActions pages through the latest action of a brokerage on each
+// ticker. name is the brokerage name or its slug.
+func (s *Service) GetBrokerageActions(ctx context.Context, name string, page *string, limit int, filter domain.StocksFilter, sort domain.StocksSort) (*domain.BrokerageActions, error) {
+
+	slug := domain.BrokerageSlug(name)
+
+	brokerage, err := s.Repository.GetBrokerage(ctx, slug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if brokerage == nil {
+		return nil, domain.NotFound("No brokerage named " + name)
+	}
+
+	scope := scopeOf(sort, filter)
+	scope.Listing = "brokerage:" + slug
+
+	cursor, err := s.decodePage(page, scope)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stocksPage, err := s.Repository.GetBrokerageActions(ctx, slug, cursor, limit, filter, sort)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stocksPage = s.encodePages(stocksPage, scope)
+
+	actions := domain.BrokerageActions{
+		Brokerage: *brokerage,
+		Items:     stocksPage.Items,
+		HasMore:   stocksPage.NextPage != "",
+	}
+
+	if stocksPage.NextPage != "" {
+		actions.NextCursor = &stocksPage.NextPage
+	}
+
+	if stocksPage.PrevPage != "" {
+		actions.PrevCursor = &stocksPage.PrevPage
+	}
+
+	return &actions, nil
+}
@@ -0,0 +1,10 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (s *Service) GetBrokerages(ctx context.Context) ([]domain.BrokerageSummary, error) {
+	return s.Repository.GetBrokerages(ctx)
+}
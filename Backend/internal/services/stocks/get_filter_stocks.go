@@ -2,16 +2,24 @@ package stocks
 
 import (
 	"backend/internal/domain"
+	"context"
 )
 
-func (s *Service) GetFilterStocks(page *string, filter *string) (*domain.StocksPage, error) {
-	limit := 10
+func (s *Service) GetFilterStocks(ctx context.Context, page *string, limit int, filter domain.StocksFilter, sort domain.StocksSort) (*domain.StocksPage, error) {
 
-	stocksPage, err := s.Repository.GetFilterStocks(page, limit, filter)
+	scope := scopeOf(sort, filter)
+
+	cursor, err := s.decodePage(page, scope)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stocksPage, err := s.Repository.GetFilterStocks(ctx, cursor, limit, filter, sort)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return stocksPage, nil
+	return s.encodePages(stocksPage, scope), nil
 }
@@ -1,14 +1,41 @@
 package stocks
 
-import "backend/internal/domain"
+import (
+	"backend/internal/domain"
+	"context"
+)
 
-func (s *Service) GetStats(filter *string, ticker *string) (*domain.StocksStats, error) {
+// GetStats counts the stocks matching filter in every target direction;
+// Pages is the number of pages of limit stocks the filtered listing has.
+// Counts are cached until the next RefreshStats.
+func (s *Service) GetStats(ctx context.Context, limit int, filter domain.StocksFilter) (*domain.StocksStats, error) {
 
-	limit := 10
-	stats, err := s.Repository.GetStats(limit, filter, ticker)
-	if err != nil {
-		return nil, err
+	counted := filter
+	counted.Target = ""
+	key := counted.String()
+
+	stats, generation, ok := s.stats.get(key)
+
+	if !ok {
+		fresh, err := s.Repository.GetStats(ctx, 0, counted)
+		if err != nil {
+			return nil, err
+		}
+
+		stats = *fresh
+		s.stats.put(key, stats, generation)
 	}
 
-	return stats, nil
+	stats.SetPages(limit, filter.Target)
+
+	return &stats, nil
+}
+
+// RefreshStats drops the cached counts and recounts the unfiltered stocks.
+// The sync calls it after every run that was not cancelled.
+func (s *Service) RefreshStats(ctx context.Context) error {
+	s.stats.reset()
+
+	_, err := s.GetStats(ctx, 0, domain.StocksFilter{})
+	return err
 }
@@ -0,0 +1,45 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+)
+
+// GetStockDetail gathers the company, the latest rating of every brokerage
+// covering ticker and their consensus.
+func (s *Service) GetStockDetail(ctx context.Context, ticker string) (*domain.StockDetail, error) {
+
+	company, err := s.Repository.GetCompany(ctx, ticker)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if company == nil {
+		return nil, domain.NotFound("No rating events for ticker " + ticker)
+	}
+
+	ratings, err := s.Repository.GetLatestRatings(ctx, ticker)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first, ties broken like the latest event of a ticker.
+	slices.SortStableFunc(*ratings, func(a, b domain.Stock) int {
+		return cmp.Or(
+			b.Time.Compare(a.Time),
+			strings.Compare(b.Brokerage, a.Brokerage),
+			strings.Compare(b.Action, a.Action),
+		)
+	})
+
+	return &domain.StockDetail{
+		Company:   *company,
+		Ratings:   *ratings,
+		Consensus: domain.NewConsensus(*ratings),
+	}, nil
+}
@@ -0,0 +1,21 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+func (s *Service) GetStockHistory(ctx context.Context, ticker string) (*[]domain.Stock, error) {
+
+	stocks, err := s.Repository.GetStockHistory(ctx, ticker)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*stocks) == 0 {
+		return nil, domain.NotFound("No rating events for ticker " + ticker)
+	}
+
+	return stocks, nil
+}
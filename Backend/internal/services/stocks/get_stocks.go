@@ -2,18 +2,25 @@ package stocks
 
 import (
 	"backend/internal/domain"
+	"context"
 )
 
-func (s *Service) GetStocks(page *string) (*domain.StocksPage, error) {
+func (s *Service) GetStocks(ctx context.Context, page *string, limit int, sort domain.StocksSort) (*domain.StocksPage, error) {
 
-	limit := 10
+	scope := scopeOf(sort, domain.StocksFilter{})
 
-	stocksPage, err := s.Repository.GetStocks(page, limit)
+	cursor, err := s.decodePage(page, scope)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return stocksPage, nil
+	stocksPage, err := s.Repository.GetStocks(ctx, cursor, limit, sort)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.encodePages(stocksPage, scope), nil
 
 }
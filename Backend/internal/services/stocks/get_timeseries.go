@@ -0,0 +1,33 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+)
+
+// GetTimeseries splits the bucketed event counts into one series per key.
+func (s *Service) GetTimeseries(ctx context.Context, query domain.TimeseriesQuery) (*domain.Timeseries, error) {
+
+	points, err := s.Repository.GetTimeseries(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	timeseries := domain.Timeseries{
+		Bucket:  query.Bucket,
+		GroupBy: query.GroupBy,
+		Series:  []domain.TimeseriesSeries{},
+	}
+
+	for _, point := range points {
+		last := len(timeseries.Series) - 1
+		if last < 0 || timeseries.Series[last].Key != point.Key {
+			timeseries.Series = append(timeseries.Series, domain.TimeseriesSeries{Key: point.Key})
+			last++
+		}
+		timeseries.Series[last].Points = append(timeseries.Series[last].Points, point)
+	}
+
+	return &timeseries, nil
+}
@@ -1,16 +1,50 @@
 package stocks
 
-import "backend/internal/domain"
+import (
+	"backend/internal/domain"
+	"context"
+	"slices"
+)
 
-func (s *Service) GetTopStocks() (*[]domain.Stock, error) {
+// GetTopStocks ranks the stocks with the named scoring strategy, or the
+// default one when strategy is empty. Rankings cover the latest rating of
+// every pair in the table, so each strategy's is computed once and cached
+// until the next RefreshTopStocks.
+func (s *Service) GetTopStocks(ctx context.Context, limit int, strategy string) ([]domain.ScoredStock, error) {
 
-	limit := 5
-
-	stocks, err := s.Repository.GetTopStocks(limit)
+	name, err := s.Scoring.Strategy(strategy)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return stocks, nil
+	ranked, generation, ok := s.top.get(name)
+
+	if !ok {
+		ratings, err := s.Repository.GetLatestRatings(ctx, "")
+
+		if err != nil {
+			return nil, err
+		}
+
+		ranked, err = s.Scoring.Rank(name, *ratings, domain.MaxTopLimit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.top.put(name, ranked, generation)
+	}
+
+	return slices.Clone(ranked[:min(limit, len(ranked))]), nil
+}
+
+// RefreshTopStocks drops the cached rankings and ranks the stocks with the
+// default strategy. The sync calls it after every run that was not
+// cancelled.
+func (s *Service) RefreshTopStocks(ctx context.Context) error {
+	s.top.reset()
+
+	_, err := s.GetTopStocks(ctx, domain.MaxTopLimit, "")
+	return err
 }
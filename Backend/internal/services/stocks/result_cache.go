@@ -0,0 +1,53 @@
+package stocks
+
+import "sync"
+
+// maxCachedResults bounds the keys a resultCache holds; it starts over
+// once it is full.
+const maxCachedResults = 1024
+
+// resultCache keeps what the service derives from the stored stocks, such
+// as stats counts and rankings, between syncs. It lives in the process:
+// every replica fills and refreshes its own after its own syncs.
+type resultCache[V any] struct {
+	mu      sync.Mutex
+	entries map[string]V
+	// generation changes on every reset, so results computed before a
+	// reset are not stored after it.
+	generation int
+}
+
+func newResultCache[V any]() *resultCache[V] {
+	return &resultCache[V]{entries: make(map[string]V)}
+}
+
+func (c *resultCache[V]) get(key string) (V, int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.entries[key]
+	return value, c.generation, ok
+}
+
+func (c *resultCache[V]) put(key string, value V, generation int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	if len(c.entries) >= maxCachedResults {
+		c.entries = make(map[string]V)
+	}
+
+	c.entries[key] = value
+}
+
+func (c *resultCache[V]) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]V)
+	c.generation++
+}
@@ -0,0 +1,18 @@
+package stocks
+
+import (
+	"backend/internal/domain"
+	"context"
+	"strings"
+)
+
+func (s *Service) Search(ctx context.Context, query string, limit int) ([]domain.SearchSuggestion, error) {
+
+	query = strings.TrimSpace(query)
+
+	if query == "" {
+		return nil, domain.InvalidArgument("Missing search query", map[string]string{"q": "is required"})
+	}
+
+	return s.Repository.Search(ctx, query, limit)
+}
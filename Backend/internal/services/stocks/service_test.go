@@ -0,0 +1,188 @@
+package stocks_test
+
+import (
+	"backend/internal/cursor"
+	"backend/internal/domain"
+	"backend/internal/provider/stock"
+	"backend/internal/provider/stock/client"
+	"backend/internal/provider/stock/fakeprovider"
+	MemoryRepository "backend/internal/repository/memory/stocks"
+	"backend/internal/repository/repotest"
+	"backend/internal/scoring"
+	"backend/internal/services/stocks"
+	"backend/internal/services/sync"
+	"context"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+var base = time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+
+// countingRepository counts the reads the caches are meant to spare.
+type countingRepository struct {
+	*MemoryRepository.Repository
+	latestRatings int
+	stats         int
+}
+
+func (r *countingRepository) GetLatestRatings(ctx context.Context, ticker string) (*[]domain.Stock, error) {
+	r.latestRatings++
+	return r.Repository.GetLatestRatings(ctx, ticker)
+}
+
+func (r *countingRepository) GetStats(ctx context.Context, limit int, filter domain.StocksFilter) (*domain.StocksStats, error) {
+	r.stats++
+	return r.Repository.GetStats(ctx, limit, filter)
+}
+
+func newService(t *testing.T, seed ...domain.Stock) (*stocks.Service, *countingRepository) {
+	t.Helper()
+
+	repo := &countingRepository{Repository: MemoryRepository.NewRepository()}
+	upsert(t, repo, seed...)
+
+	service := stocks.NewService(nil, repo, cursor.NewCodec("test"), scoring.NewEngine(scoring.DefaultConfig()))
+
+	return service, repo
+}
+
+func upsert(t *testing.T, repo *countingRepository, seed ...domain.Stock) {
+	t.Helper()
+
+	if err := repo.Upsert(context.Background(), seed); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+}
+
+func TestGetTopStocksCachesRankings(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newService(t,
+		repotest.Stock("AAA", "Citi", "upgraded by", "Buy", 10, 14, base),
+		repotest.Stock("BBB", "Citi", "upgraded by", "Buy", 10, 12, base),
+	)
+
+	top, err := service.GetTopStocks(ctx, 1, "")
+	if err != nil {
+		t.Fatalf("GetTopStocks: %v", err)
+	}
+	if len(top) != 1 || top[0].Ticker != "AAA" {
+		t.Fatalf("top = %+v, want AAA alone", top)
+	}
+
+	if top, err = service.GetTopStocks(ctx, 5, ""); err != nil || len(top) != 2 {
+		t.Fatalf("GetTopStocks(5) = %d stocks, %v; want 2", len(top), err)
+	}
+
+	if repo.latestRatings != 1 {
+		t.Fatalf("loaded ratings %d times, want once for both limits", repo.latestRatings)
+	}
+
+	if _, err := service.GetTopStocks(ctx, 5, "nope"); err == nil {
+		t.Fatal("unknown strategy accepted")
+	}
+	if repo.latestRatings != 1 {
+		t.Fatal("an unknown strategy loaded the ratings")
+	}
+
+	upsert(t, repo, repotest.Stock("CCC", "Citi", "upgraded by", "Strong-Buy", 10, 20, base))
+
+	if err := service.RefreshTopStocks(ctx); err != nil {
+		t.Fatalf("RefreshTopStocks: %v", err)
+	}
+
+	top, err = service.GetTopStocks(ctx, 1, "")
+	if err != nil || top[0].Ticker != "CCC" {
+		t.Fatalf("top after refresh = %+v, %v; want CCC first", top, err)
+	}
+
+	if repo.latestRatings != 2 {
+		t.Fatalf("loaded ratings %d times, want once more for the refresh", repo.latestRatings)
+	}
+}
+
+// noCheckpoints lets a sync run from the first page every time.
+type noCheckpoints struct{}
+
+func (noCheckpoints) GetCheckpoint(ctx context.Context) (*string, error) {
+	return nil, nil
+}
+
+func (noCheckpoints) SaveCheckpoint(ctx context.Context, cursor string) error {
+	return nil
+}
+
+func (noCheckpoints) ClearCheckpoint(ctx context.Context) error {
+	return nil
+}
+
+func TestGetStatsCachesUntilSync(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newService(t,
+		repotest.Stock("AAA", "Citi", "upgraded by", "Buy", 10, 14, base),
+	)
+
+	server := httptest.NewServer(fakeprovider.New(fakeprovider.Options{Pages: 2, PageSize: 5, Seed: 7}))
+	t.Cleanup(server.Close)
+
+	providerClient := client.NewClient(server.URL, "", client.RetryPolicy{}, nil)
+	syncService := sync.NewService(stock.NewProvider(providerClient), repo, noCheckpoints{}, 2, 5)
+	syncService.RefreshAfterRun(service.RefreshStats)
+
+	stats, err := service.GetStats(ctx, 10, domain.StocksFilter{})
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	if stats.AllStocks != 1 || repo.stats != 1 {
+		t.Fatalf("first load counted %d stocks in %d reads, want 1 in 1", stats.AllStocks, repo.stats)
+	}
+
+	// Writes outside a sync are not seen until the next refresh.
+	upsert(t, repo, repotest.Stock("BBB", "Citi", "upgraded by", "Buy", 10, 12, base))
+
+	if stats, err = service.GetStats(ctx, 10, domain.StocksFilter{}); err != nil || stats.AllStocks != 1 {
+		t.Fatalf("cached GetStats = %+v, %v; want the first count", stats, err)
+	}
+	if repo.stats != 1 {
+		t.Fatalf("cached GetStats read the repository: %d reads", repo.stats)
+	}
+
+	if _, err := syncService.Run(ctx, false); err != nil {
+		t.Fatalf("sync Run: %v", err)
+	}
+	if repo.stats != 2 {
+		t.Fatalf("the sync refreshed the stats in %d reads, want 2 in all", repo.stats)
+	}
+
+	stats, err = service.GetStats(ctx, 10, domain.StocksFilter{})
+	if err != nil {
+		t.Fatalf("GetStats after sync: %v", err)
+	}
+	if stats.AllStocks <= 2 || repo.stats != 2 {
+		t.Fatalf("after sync counted %d stocks in %d reads, want the synced stocks from the refreshed cache", stats.AllStocks, repo.stats)
+	}
+}
+
+func TestGetStockDetailOrdersTies(t *testing.T) {
+	service, _ := newService(t,
+		repotest.Stock("AAA", "Citi", "upgraded by", "Buy", 10, 14, base),
+		repotest.Stock("AAA", "UBS", "initiated by", "Hold", 10, 12, base),
+		repotest.Stock("AAA", "Barclays", "upgraded by", "Buy", 10, 11, base.Add(-time.Hour)),
+		repotest.Stock("AAA", "Jefferies", "downgraded by", "Sell", 10, 9, base),
+	)
+
+	detail, err := service.GetStockDetail(context.Background(), "AAA")
+	if err != nil {
+		t.Fatalf("GetStockDetail: %v", err)
+	}
+
+	var got []string
+	for _, rating := range detail.Ratings {
+		got = append(got, rating.Brokerage)
+	}
+
+	if want := []string{"UBS", "Jefferies", "Citi", "Barclays"}; !slices.Equal(got, want) {
+		t.Fatalf("ratings = %v, want %v", got, want)
+	}
+}
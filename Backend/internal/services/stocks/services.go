@@ -1,15 +1,30 @@
 package stocks
 
-import "backend/internal/ports"
+import (
+	"backend/internal/cursor"
+	"backend/internal/domain"
+	"backend/internal/ports"
+	"backend/internal/scoring"
+)
 
 type Service struct {
 	Provider   ports.StockProvider
 	Repository ports.StocksRepository
+	Cursors    *cursor.Codec
+	Scoring    *scoring.Engine
+
+	// Keyed by the counted filter and by strategy, until the next refresh.
+	stats *resultCache[domain.StocksStats]
+	top   *resultCache[[]domain.ScoredStock]
 }
 
-func NewService(provider ports.StockProvider, repository ports.StocksRepository) *Service {
+func NewService(provider ports.StockProvider, repository ports.StocksRepository, cursors *cursor.Codec, scoring *scoring.Engine) *Service {
 	return &Service{
 		Provider:   provider,
 		Repository: repository,
+		Cursors:    cursors,
+		Scoring:    scoring,
+		stats:      newResultCache[domain.StocksStats](),
+		top:        newResultCache[[]domain.ScoredStock](),
 	}
 }
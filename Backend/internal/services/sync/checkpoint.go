@@ -0,0 +1,47 @@
+package sync
+
+import "sync"
+
+// checkpointTracker saves the resume cursor of the newest batch whose
+// predecessors are all stored. Workers finish batches out of order, so a
+// batch only counts once the gap before it is closed.
+type checkpointTracker struct {
+	mu        sync.Mutex
+	next      int
+	committed map[int]*string
+	save      func(cursor string)
+}
+
+func newCheckpointTracker(save func(cursor string)) *checkpointTracker {
+	return &checkpointTracker{
+		committed: make(map[int]*string),
+		save:      save,
+	}
+}
+
+func (t *checkpointTracker) commit(seq int, cursor *string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.committed[seq] = cursor
+
+	advanced := false
+	var latest *string
+
+	for {
+		c, ok := t.committed[t.next]
+		if !ok {
+			break
+		}
+
+		delete(t.committed, t.next)
+		t.next++
+		latest = c
+		advanced = true
+	}
+
+	// A nil cursor means the first page is not fully stored yet.
+	if advanced && latest != nil && *latest != "" {
+		t.save(*latest)
+	}
+}
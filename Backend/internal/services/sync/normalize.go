@@ -0,0 +1,19 @@
+package sync
+
+import (
+	"backend/internal/domain"
+	"backend/internal/money"
+)
+
+// normalize parses the provider price targets into numbers and maps the
+// ratings onto the canonical scale before they are stored. Targets and
+// ratings that cannot be parsed keep only their raw text.
+func normalize(stock domain.Stock) domain.Stock {
+	stock.TargetFrom = money.ParsePtr(stock.TargetFromRaw)
+	stock.TargetTo = money.ParsePtr(stock.TargetToRaw)
+	stock.TargetChange = domain.PercentChange(stock.TargetFrom, stock.TargetTo)
+	stock.RatingFromCanonical = domain.NormalizeRating(stock.RatingFrom)
+	stock.RatingToCanonical = domain.NormalizeRating(stock.RatingTo)
+
+	return stock
+}
@@ -2,22 +2,80 @@ package sync
 
 import (
 	"backend/internal/domain"
+	"backend/internal/provider/stock/client"
+	"context"
 	"errors"
+	"fmt"
+	"log"
 	"sync"
+	"time"
 )
 
-func (s *Service) Run() error {
+const (
+	upsertTimeout     = 5 * time.Second
+	checkpointTimeout = 5 * time.Second
+	// afterRunTimeout bounds each AfterRun and RefreshAfterRun hook.
+	afterRunTimeout = 30 * time.Second
+)
+
+// ErrPageLoop ends a run whose provider pages point back to one already
+// fetched. The pages fetched until then are stored and the checkpoint kept.
+var ErrPageLoop = errors.New("sync: provider pages loop")
+
+// batch is a group of stocks upserted together. cursor is where a later
+// run can resume once this batch and every batch before it are stored.
+type batch struct {
+	seq    int
+	stocks []domain.Stock
+	cursor *string
+}
+
+// Run fetches every provider page and upserts it in batches. With resume
+// set it starts from the checkpoint left by an interrupted run. Cancelling
+// ctx stops fetching and returns ctx's error.
+func (s *Service) Run(ctx context.Context, resume bool) (domain.SyncResult, error) {
 	batchSize := s.BatchSize
 	workers := s.Workers
 
 	if workers <= 0 {
-		return errors.New("sync: WORKERS must be > 0")
+		return domain.SyncResult{}, errors.New("sync: WORKERS must be > 0")
 	}
 	if batchSize <= 0 {
-		return errors.New("sync: BATCH_SIZE must be > 0")
+		return domain.SyncResult{}, errors.New("sync: BATCH_SIZE must be > 0")
+	}
+
+	s.pagesFetched.Store(0)
+	s.stocksUpserted.Store(0)
+	s.limiterWaitStart.Store(int64(s.limiterWait()))
+	s.resetUnknownRatings()
+
+	defer s.runAfterRun(ctx)
+
+	var page *string
+
+	if resume {
+		checkpoint, err := s.Checkpoints.GetCheckpoint(ctx)
+		if err != nil {
+			return domain.SyncResult{}, err
+		}
+		if checkpoint != nil {
+			log.Printf("[SYNC] resuming from checkpoint %q", *checkpoint)
+			page = checkpoint
+		}
 	}
 
-	batchesCh := make(chan []domain.Stock)
+	tracker := newCheckpointTracker(func(cursor string) {
+		// A stored batch must be checkpointed even when the run is being
+		// cancelled, otherwise the next resume repeats it.
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), checkpointTimeout)
+		defer cancel()
+
+		if err := s.Checkpoints.SaveCheckpoint(saveCtx, cursor); err != nil {
+			log.Printf("[SYNC] failed to save checkpoint: %v", err)
+		}
+	})
+
+	batchesCh := make(chan batch)
 	errCh := make(chan error, 1)
 	stopCh := make(chan struct{})
 	var stopOnce sync.Once
@@ -43,10 +101,18 @@ func (s *Service) Run() error {
 			defer wg.Done()
 
 			for batch := range batchesCh {
-				if err := s.Repository.Upsert(batch); err != nil {
+				// A batch already handed to a worker is finished even if the
+				// run is cancelled, so shutdown never leaves it half written.
+				upsertCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), upsertTimeout)
+				err := s.Repository.Upsert(upsertCtx, batch.stocks)
+				cancel()
+
+				if err != nil {
 					fail(err)
 					return
 				}
+				s.stocksUpserted.Add(int64(len(batch.stocks)))
+				tracker.commit(batch.seq, batch.cursor)
 			}
 		}()
 	}
@@ -56,57 +122,90 @@ func (s *Service) Run() error {
 		defer close(producerDone)
 		defer close(batchesCh)
 
-		var page *string
 		var buffer []domain.Stock
+		var seq int
 		seenPages := make(map[string]bool)
 
+		send := func(cursor *string) bool {
+			select {
+			case <-stopCh:
+				return false
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return false
+			case batchesCh <- batch{seq: seq, stocks: buffer, cursor: cursor}:
+			}
+			seq++
+			buffer = nil
+			return true
+		}
+
 		for {
 			select {
 			case <-stopCh:
 				return
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return
 			default:
 			}
 
-			stocksPage, err := s.Provider.FetchStocks(page)
+			pageCursor := page
+
+			stocksPage, err := s.Provider.FetchStocks(ctx, page)
 			if err != nil {
-				fail(err)
+				fail(fetchError(err))
 				return
 			}
+			s.pagesFetched.Add(1)
 
-			if stocksPage.NextPage != "" {
-				if seenPages[stocksPage.NextPage] {
-					break
-				}
+			// A next_page already followed would never end. This page is
+			// still stored, and checkpointed as the place to resume.
+			looped := stocksPage.NextPage != "" && seenPages[stocksPage.NextPage]
+
+			switch {
+			case looped:
+				page = pageCursor
+			case stocksPage.NextPage != "":
 				seenPages[stocksPage.NextPage] = true
 				page = &stocksPage.NextPage
-			} else {
+			default:
 				page = nil
 			}
 
-			for _, stock := range stocksPage.Items {
+			for i, stock := range stocksPage.Items {
+				stock = normalize(stock)
+				s.noteUnknownRatings(stock)
 				buffer = append(buffer, stock)
 
 				if len(buffer) == batchSize {
-					select {
-					case <-stopCh:
+					// A batch ending mid-page resumes by fetching that page
+					// again; upserts make the repeated items harmless.
+					cursor := pageCursor
+					if i == len(stocksPage.Items)-1 {
+						cursor = page
+					}
+					if !send(cursor) {
 						return
-					case batchesCh <- buffer:
 					}
-					buffer = nil
 				}
 			}
 
+			if looped {
+				if len(buffer) > 0 && !send(page) {
+					return
+				}
+				fail(fmt.Errorf("%w: next_page %q was already fetched", ErrPageLoop, stocksPage.NextPage))
+				return
+			}
+
 			if page == nil {
 				break
 			}
 		}
 
 		if len(buffer) > 0 {
-			select {
-			case <-stopCh:
-				return
-			case batchesCh <- buffer:
-			}
+			send(page)
 		}
 	}()
 
@@ -115,10 +214,34 @@ func (s *Service) Run() error {
 	<-producerDone
 	wg.Wait()
 
+	result := s.Progress()
+
 	select {
 	case err := <-errCh:
-		return err
+		return result, err
 	default:
-		return nil
+	}
+
+	if err := s.Checkpoints.ClearCheckpoint(ctx); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// fetchError explains why the run stops. The client has already retried
+// transient failures, so any error ends the run; rate limits and upstream
+// outages keep the checkpoint for the next resume, while rejected
+// credentials need someone to fix AUTHENTICATION first.
+func fetchError(err error) error {
+	switch {
+	case errors.Is(err, client.ErrUnauthorized):
+		return fmt.Errorf("sync: provider rejected the credentials, check AUTHENTICATION: %w", err)
+	case errors.Is(err, client.ErrRateLimited):
+		return fmt.Errorf("sync: provider still rate limiting after retries, the next resume continues from the checkpoint: %w", err)
+	case errors.Is(err, client.ErrUpstream):
+		return fmt.Errorf("sync: provider unavailable after retries, the next resume continues from the checkpoint: %w", err)
+	default:
+		return err
 	}
 }
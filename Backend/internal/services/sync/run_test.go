@@ -0,0 +1,260 @@
+package sync_test
+
+import (
+	"backend/internal/domain"
+	"backend/internal/provider/stock"
+	"backend/internal/provider/stock/client"
+	"backend/internal/provider/stock/fakeprovider"
+	MemoryRepository "backend/internal/repository/memory/stocks"
+	"backend/internal/services/sync"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	stdsync "sync"
+	"testing"
+	"time"
+)
+
+type memoryCheckpoints struct {
+	mu     stdsync.Mutex
+	cursor *string
+}
+
+func (m *memoryCheckpoints) GetCheckpoint(ctx context.Context) (*string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cursor, nil
+}
+
+func (m *memoryCheckpoints) SaveCheckpoint(ctx context.Context, cursor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cursor = &cursor
+	return nil
+}
+
+func (m *memoryCheckpoints) ClearCheckpoint(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cursor = nil
+	return nil
+}
+
+type fixture struct {
+	fake        *fakeprovider.Server
+	repo        *MemoryRepository.Repository
+	checkpoints *memoryCheckpoints
+	service     *sync.Service
+}
+
+func newFixture(t *testing.T, opts fakeprovider.Options, maxRetries, batchSize int) *fixture {
+	t.Helper()
+
+	fake := fakeprovider.New(opts)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	providerClient := client.NewClient(server.URL, "", client.RetryPolicy{
+		MaxRetries: maxRetries,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   5 * time.Millisecond,
+	}, nil)
+
+	repo := MemoryRepository.NewRepository()
+	checkpoints := &memoryCheckpoints{}
+
+	return &fixture{
+		fake:        fake,
+		repo:        repo,
+		checkpoints: checkpoints,
+		service:     sync.NewService(stock.NewProvider(providerClient), repo, checkpoints, 3, batchSize),
+	}
+}
+
+func (f *fixture) storedStocks(t *testing.T) int {
+	t.Helper()
+
+	page, err := f.repo.GetStocks(context.Background(), nil, 1000, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetStocks: %v", err)
+	}
+
+	return len(page.Items)
+}
+
+func TestRunStoresEveryPage(t *testing.T) {
+	f := newFixture(t, fakeprovider.Options{Pages: 5, PageSize: 7, Seed: 42}, 0, 4)
+
+	result, err := f.service.Run(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if result.PagesFetched != 5 || result.StocksUpserted != 35 {
+		t.Fatalf("result = %+v, want 5 pages and 35 stocks", result)
+	}
+
+	if got := f.storedStocks(t); got != 35 {
+		t.Fatalf("stored %d stocks, want 35", got)
+	}
+
+	if len(result.UnknownRatings) != 0 {
+		t.Fatalf("unknown ratings %v, want the provider vocabulary known", result.UnknownRatings)
+	}
+
+	page, err := f.repo.GetFilterStocks(context.Background(), nil, 1000, domain.StocksFilter{MinRating: domain.RatingStrongSell}, domain.DefaultStocksSort)
+	if err != nil {
+		t.Fatalf("GetFilterStocks: %v", err)
+	}
+	if len(page.Items) != 35 {
+		t.Fatalf("%d stocks have a canonical rating, want 35", len(page.Items))
+	}
+
+	if f.checkpoints.cursor != nil {
+		t.Fatalf("checkpoint %q left after a complete run", *f.checkpoints.cursor)
+	}
+}
+
+func TestRunRetriesTransientFailures(t *testing.T) {
+	f := newFixture(t, fakeprovider.Options{Pages: 3, PageSize: 5}, 3, 5)
+	f.fake.Script(fakeprovider.FaultRateLimit, fakeprovider.FaultServerError, fakeprovider.FaultMalformed)
+
+	_, err := f.service.Run(context.Background(), false)
+	if err == nil {
+		t.Fatal("Run succeeded, want the malformed page to fail it")
+	}
+
+	f.fake.Script(fakeprovider.FaultRateLimit, fakeprovider.FaultServerError)
+
+	result, err := f.service.Run(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if result.PagesFetched != 3 || f.storedStocks(t) != 15 {
+		t.Fatalf("result = %+v, stored %d; want 3 pages and 15 stocks", result, f.storedStocks(t))
+	}
+}
+
+func TestRunStopsOnCursorLoop(t *testing.T) {
+	f := newFixture(t, fakeprovider.Options{Pages: 5, PageSize: 2, LoopAt: 3}, 0, 2)
+
+	done := make(chan struct{})
+	var runErr error
+
+	go func() {
+		defer close(done)
+		_, runErr = f.service.Run(context.Background(), false)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not stop on a next_page loop")
+	}
+
+	if f.fake.Requests() != 3 {
+		t.Fatalf("made %d requests, want the loop detected on the third page", f.fake.Requests())
+	}
+
+	if !errors.Is(runErr, sync.ErrPageLoop) {
+		t.Fatalf("Run = %v, want ErrPageLoop", runErr)
+	}
+
+	if got := f.storedStocks(t); got != 6 {
+		t.Fatalf("stored %d stocks, want the 6 of the pages fetched", got)
+	}
+
+	if f.checkpoints.cursor == nil || *f.checkpoints.cursor != "page-2" {
+		t.Fatalf("checkpoint = %v, want page-2 kept", f.checkpoints.cursor)
+	}
+}
+
+func TestRunResumesFromCheckpoint(t *testing.T) {
+	f := newFixture(t, fakeprovider.Options{Pages: 5, PageSize: 4}, 0, 4)
+	f.fake.Script(fakeprovider.FaultNone, fakeprovider.FaultNone, fakeprovider.FaultServerError)
+
+	if _, err := f.service.Run(context.Background(), true); err == nil {
+		t.Fatal("Run succeeded, want the third page to fail it")
+	}
+
+	if f.checkpoints.cursor == nil || *f.checkpoints.cursor != "page-2" {
+		t.Fatalf("checkpoint = %v, want page-2", f.checkpoints.cursor)
+	}
+
+	result, err := f.service.Run(context.Background(), true)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if result.PagesFetched != 3 {
+		t.Fatalf("resumed run fetched %d pages, want 3", result.PagesFetched)
+	}
+
+	if got := f.storedStocks(t); got != 20 {
+		t.Fatalf("stored %d stocks, want 20", got)
+	}
+}
+
+func TestRunCallsAfterRunHooks(t *testing.T) {
+	f := newFixture(t, fakeprovider.Options{Pages: 2, PageSize: 3}, 0, 3)
+	f.fake.Script(fakeprovider.FaultServerError)
+
+	var calls []string
+	var hookVersion string
+	f.service.AfterRun(func(ctx context.Context) error {
+		calls = append(calls, "first")
+		return nil
+	})
+	f.service.AfterRun(func(ctx context.Context) error {
+		calls = append(calls, "second")
+		hookVersion = f.service.DataVersion()
+		return nil
+	})
+
+	if _, err := f.service.Run(context.Background(), false); err == nil {
+		t.Fatal("Run succeeded, want the first page to fail it")
+	}
+
+	if _, err := f.service.Run(context.Background(), false); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if want := []string{"first", "second", "first", "second"}; !slices.Equal(calls, want) {
+		t.Fatalf("hooks called %v, want %v", calls, want)
+	}
+
+	if f.service.DataVersion() == hookVersion {
+		t.Fatal("DataVersion did not move on after the hooks")
+	}
+}
+
+func TestCancelledRunSkipsRefreshHooks(t *testing.T) {
+	f := newFixture(t, fakeprovider.Options{Pages: 2, PageSize: 3}, 0, 3)
+
+	var calls []string
+	f.service.AfterRun(func(ctx context.Context) error {
+		calls = append(calls, "invalidate")
+		return nil
+	})
+	f.service.RefreshAfterRun(func(ctx context.Context) error {
+		calls = append(calls, "refresh")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := f.service.Run(ctx, false); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run = %v, want context.Canceled", err)
+	}
+
+	if _, err := f.service.Run(context.Background(), false); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if want := []string{"invalidate", "invalidate", "refresh"}; !slices.Equal(calls, want) {
+		t.Fatalf("hooks called %v, want %v", calls, want)
+	}
+}
@@ -0,0 +1,262 @@
+package sync
+
+import (
+	"backend/internal/domain"
+	"backend/internal/ports"
+	"backend/internal/schedule"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrRunInProgress = fmt.Errorf("sync: a run is already in progress: %w", domain.ErrConflict)
+
+// ErrStopped is returned by Trigger once the context given to Start is done.
+var ErrStopped = fmt.Errorf("sync: the scheduler is stopping: %w", domain.ErrConflict)
+
+// Scheduler runs the sync once at start and then whenever the schedule
+// says so. A tick that arrives while a run is still going is skipped.
+type Scheduler struct {
+	Service  *Service
+	Runs     ports.SyncRunsRepository
+	Schedule schedule.Schedule
+	// Resume makes scheduled runs continue from the last checkpoint.
+	Resume bool
+
+	running atomic.Bool
+	// runs tracks the schedule loop and every run so Wait can block on them.
+	runs sync.WaitGroup
+
+	mu      sync.Mutex
+	ctx     context.Context
+	lastRun *domain.SyncRun
+}
+
+// NewScheduler builds a scheduler. A nil schedule runs the sync only once.
+func NewScheduler(service *Service, runs ports.SyncRunsRepository, sched schedule.Schedule, resume bool) *Scheduler {
+	return &Scheduler{
+		Service:  service,
+		Runs:     runs,
+		Schedule: sched,
+		Resume:   resume,
+		ctx:      context.Background(),
+	}
+}
+
+// Start launches the schedule loop. Cancelling ctx stops the loop and every
+// run started by the scheduler, including manual triggers.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.runs.Add(1)
+	s.mu.Unlock()
+
+	go func() {
+		defer s.runs.Done()
+
+		for {
+			if s.running.CompareAndSwap(false, true) {
+				s.run(ctx, s.begin(), s.Resume)
+			} else {
+				log.Printf("[SYNC] previous run still in progress, skipping")
+			}
+
+			if s.Schedule == nil {
+				return
+			}
+
+			next := s.Schedule.Next(time.Now())
+			if next.IsZero() {
+				log.Printf("[SYNC] schedule has no next run, stopping")
+				return
+			}
+
+			log.Printf("[SYNC] next run at %s", next.Format(time.RFC3339))
+
+			timer := time.NewTimer(time.Until(next))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// Trigger starts a run in the background right away. It returns
+// ErrRunInProgress when another run has not finished yet and ErrStopped
+// once the scheduler is stopping.
+func (s *Scheduler) Trigger(resume bool) error {
+	if !s.running.CompareAndSwap(false, true) {
+		return ErrRunInProgress
+	}
+
+	// The check and the Add share the lock with Wait, so no run is added
+	// once Wait may be waiting.
+	s.mu.Lock()
+	ctx := s.ctx
+	if ctx.Err() != nil {
+		s.mu.Unlock()
+		s.running.Store(false)
+		return ErrStopped
+	}
+	s.runs.Add(1)
+	s.mu.Unlock()
+
+	// Recorded before returning so a status read right after Trigger sees
+	// this run rather than the previous one.
+	run := s.begin()
+
+	go func() {
+		defer s.runs.Done()
+		s.run(ctx, run, resume)
+	}()
+
+	return nil
+}
+
+// Wait blocks until the schedule loop and any run in progress have returned,
+// or until ctx ends. Call it after cancelling the context given to Start.
+func (s *Scheduler) Wait(ctx context.Context) error {
+	// Any Trigger holding the lock has added its run by now, and later ones
+	// see the cancelled context.
+	s.mu.Lock()
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.runs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Status reports the run in progress with its live counters, otherwise the
+// last finished run, falling back to sync_runs after a restart.
+func (s *Scheduler) Status(ctx context.Context) (*domain.SyncStatus, error) {
+	s.mu.Lock()
+	run := s.lastRun
+	s.mu.Unlock()
+
+	if run == nil {
+		last, err := s.Runs.GetLastRun(ctx)
+		if err != nil {
+			return nil, err
+		}
+		run = last
+	}
+
+	if run == nil {
+		return &domain.SyncStatus{State: domain.SyncStateIdle}, nil
+	}
+
+	status := domain.SyncStatus{
+		StartedAt:      &run.StartedAt,
+		FinishedAt:     run.FinishedAt,
+		PagesFetched:   run.PagesFetched,
+		StocksUpserted: run.StocksUpserted,
+		LimiterWaitMs:  run.LimiterWaitMs,
+		UnknownRatings: run.UnknownRatings,
+		LastError:      run.Error,
+	}
+
+	switch {
+	case run.FinishedAt == nil && s.running.Load():
+		progress := s.Service.Progress()
+		status.State = domain.SyncStateRunning
+		status.PagesFetched = progress.PagesFetched
+		status.StocksUpserted = progress.StocksUpserted
+		status.LimiterWaitMs = progress.LimiterWaitMs
+		status.UnknownRatings = progress.UnknownRatings
+		status.DurationSeconds = time.Since(run.StartedAt).Seconds()
+	case run.Error != nil:
+		status.State = domain.SyncStateFailed
+	case run.FinishedAt == nil:
+		// Started by a process that stopped before recording the end.
+		status.State = domain.SyncStateFailed
+	default:
+		status.State = domain.SyncStateSucceeded
+	}
+
+	if run.FinishedAt != nil {
+		status.DurationSeconds = run.FinishedAt.Sub(run.StartedAt).Seconds()
+	}
+
+	return &status, nil
+}
+
+// begin makes a new run, not finished yet, the last run.
+func (s *Scheduler) begin() domain.SyncRun {
+	run := domain.SyncRun{StartedAt: time.Now().UTC()}
+	s.setLastRun(run)
+
+	return run
+}
+
+// run executes the run begin made and records it in sync_runs. The caller
+// must have set running; it is cleared when the run ends.
+func (s *Scheduler) run(ctx context.Context, run domain.SyncRun, resume bool) {
+	defer s.running.Store(false)
+
+	id, err := s.Runs.StartRun(ctx, run.StartedAt)
+	if err != nil {
+		log.Printf("[SYNC] failed to record run start: %v", err)
+	}
+	run.ID = id
+
+	log.Printf("[SYNC] starting (workers=%d, batchSize=%d, resume=%t)", s.Service.Workers, s.Service.BatchSize, resume)
+
+	result, err := s.Service.Run(ctx, resume)
+
+	finishedAt := time.Now().UTC()
+	run.FinishedAt = &finishedAt
+	run.PagesFetched = result.PagesFetched
+	run.StocksUpserted = result.StocksUpserted
+	run.LimiterWaitMs = result.LimiterWaitMs
+	run.UnknownRatings = result.UnknownRatings
+
+	if len(result.UnknownRatings) > 0 {
+		log.Printf("[SYNC] %d ratings have no canonical rating: %s", len(result.UnknownRatings), strings.Join(result.UnknownRatings, ", "))
+	}
+
+	if err != nil {
+		message := err.Error()
+		run.Error = &message
+		log.Printf("[SYNC] failed: %v", err)
+	} else {
+		log.Printf("[SYNC] finished successfully (pages=%d, stocks=%d, limiterWait=%dms)", result.PagesFetched, result.StocksUpserted, result.LimiterWaitMs)
+	}
+
+	s.setLastRun(run)
+
+	if run.ID != 0 {
+		// Recorded even when ctx was cancelled so the run does not look
+		// like it is still going.
+		finishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+
+		if err := s.Runs.FinishRun(finishCtx, run); err != nil {
+			log.Printf("[SYNC] failed to record run end: %v", err)
+		}
+	}
+}
+
+func (s *Scheduler) setLastRun(run domain.SyncRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastRun = &run
+}
@@ -0,0 +1,90 @@
+package sync_test
+
+import (
+	"backend/internal/domain"
+	"backend/internal/provider/stock/fakeprovider"
+	"backend/internal/services/sync"
+	"context"
+	"errors"
+	stdsync "sync"
+	"testing"
+	"time"
+)
+
+type memoryRuns struct {
+	mu   stdsync.Mutex
+	runs []domain.SyncRun
+}
+
+func (m *memoryRuns) StartRun(ctx context.Context, startedAt time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.runs = append(m.runs, domain.SyncRun{ID: int64(len(m.runs) + 1), StartedAt: startedAt})
+	return int64(len(m.runs)), nil
+}
+
+func (m *memoryRuns) FinishRun(ctx context.Context, run domain.SyncRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.runs[run.ID-1] = run
+	return nil
+}
+
+func (m *memoryRuns) GetLastRun(ctx context.Context) (*domain.SyncRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.runs) == 0 {
+		return nil, nil
+	}
+	run := m.runs[len(m.runs)-1]
+	return &run, nil
+}
+
+func TestTriggerReportsTheNewRun(t *testing.T) {
+	f := newFixture(t, fakeprovider.Options{Pages: 2, PageSize: 2, Latency: 20 * time.Millisecond}, 0, 2)
+	scheduler := sync.NewScheduler(f.service, &memoryRuns{}, nil, false)
+	ctx := context.Background()
+
+	for range 2 {
+		if err := scheduler.Trigger(false); err != nil {
+			t.Fatalf("Trigger: %v", err)
+		}
+
+		status, err := scheduler.Status(ctx)
+		if err != nil {
+			t.Fatalf("Status: %v", err)
+		}
+		if status.State != domain.SyncStateRunning {
+			t.Fatalf("state right after Trigger = %q, want %q", status.State, domain.SyncStateRunning)
+		}
+
+		if err := scheduler.Wait(ctx); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+
+	status, err := scheduler.Status(ctx)
+	if err != nil || status.State != domain.SyncStateSucceeded {
+		t.Fatalf("state after the run = %+v, %v; want %q", status, err, domain.SyncStateSucceeded)
+	}
+}
+
+func TestTriggerAfterStop(t *testing.T) {
+	f := newFixture(t, fakeprovider.Options{Pages: 1, PageSize: 2}, 0, 2)
+	scheduler := sync.NewScheduler(f.service, &memoryRuns{}, nil, false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(ctx)
+	cancel()
+
+	if err := scheduler.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	if err := scheduler.Trigger(false); !errors.Is(err, sync.ErrStopped) {
+		t.Fatalf("Trigger after stop = %v, want ErrStopped", err)
+	}
+}
@@ -1,19 +1,172 @@
 package sync
 
-import "backend/internal/ports"
+import (
+	"backend/internal/domain"
+	"backend/internal/ports"
+	"context"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 type Service struct {
-	Provider   ports.StockProvider
-	Repository ports.StocksRepository
-	Workers    int
-	BatchSize  int
+	Provider    ports.StockProvider
+	Repository  ports.StocksRepository
+	Checkpoints ports.SyncCheckpointsRepository
+	Workers     int
+	BatchSize   int
+
+	// Counters of the run in progress, reset when Run starts.
+	pagesFetched   atomic.Int64
+	stocksUpserted atomic.Int64
+	// Provider limiter wait total when the run started.
+	limiterWaitStart atomic.Int64
+
+	// Raw ratings of the run that have no canonical rating.
+	mu             sync.Mutex
+	unknownRatings map[string]bool
+
+	hooksMu  sync.Mutex
+	afterRun []afterRunHook
+
+	// dataVersion is when this process last saw the stored stocks change:
+	// its start, then the end of every run's after-run hooks.
+	dataVersion atomic.Int64
+}
+
+func NewService(provider ports.StockProvider, repository ports.StocksRepository, checkpoints ports.SyncCheckpointsRepository, workers int, batchSize int) *Service {
+	s := &Service{
+		Provider:    provider,
+		Repository:  repository,
+		Checkpoints: checkpoints,
+		Workers:     workers,
+		BatchSize:   batchSize,
+	}
+	s.dataVersion.Store(time.Now().UnixNano())
+
+	return s
+}
+
+// DataVersion identifies the stocks the process answers from. It changes
+// once a run's after-run hooks have refreshed every cache, so answers tagged
+// with it never outlive the data they were built from. It is per process,
+// like those caches.
+func (s *Service) DataVersion() string {
+	return strconv.FormatInt(s.dataVersion.Load(), 36)
+}
+
+// Progress reports what the current (or last) run has done so far.
+func (s *Service) Progress() domain.SyncResult {
+	return domain.SyncResult{
+		PagesFetched:   int(s.pagesFetched.Load()),
+		StocksUpserted: int(s.stocksUpserted.Load()),
+		LimiterWaitMs:  (s.limiterWait() - time.Duration(s.limiterWaitStart.Load())).Milliseconds(),
+		UnknownRatings: s.unknownRatingsSeen(),
+	}
+}
+
+type afterRunHook struct {
+	fn func(ctx context.Context) error
+	// refresh hooks are skipped when the run was cancelled.
+	refresh bool
+}
+
+// AfterRun registers fn to run whenever Run returns, failed and cancelled
+// runs included since they may have stored part of the data. It is meant
+// for cheap work such as invalidating caches.
+func (s *Service) AfterRun(fn func(ctx context.Context) error) {
+	s.addAfterRun(afterRunHook{fn: fn})
+}
+
+// RefreshAfterRun registers fn like AfterRun, except that it is skipped
+// when the run was cancelled: recomputing what is derived from the stored
+// stocks would compete with a shutdown, and the next start does it anyway.
+func (s *Service) RefreshAfterRun(fn func(ctx context.Context) error) {
+	s.addAfterRun(afterRunHook{fn: fn, refresh: true})
+}
+
+func (s *Service) addAfterRun(hook afterRunHook) {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+
+	s.afterRun = append(s.afterRun, hook)
+}
+
+// runAfterRun calls the hooks in registration order, then moves DataVersion
+// on. AfterRun hooks run even when ctx was cancelled, each with its own
+// deadline.
+func (s *Service) runAfterRun(ctx context.Context) {
+	s.hooksMu.Lock()
+	hooks := s.afterRun
+	s.hooksMu.Unlock()
+
+	cancelled := ctx.Err() != nil
+
+	for _, hook := range hooks {
+		if hook.refresh && cancelled {
+			continue
+		}
+
+		hookCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), afterRunTimeout)
+		err := hook.fn(hookCtx)
+		cancel()
+
+		if err != nil {
+			log.Printf("[SYNC] after-run hook failed: %v", err)
+		}
+	}
+
+	s.dataVersion.Store(time.Now().UnixNano())
+}
+
+func (s *Service) resetUnknownRatings() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unknownRatings = make(map[string]bool)
 }
 
-func NewService(provider ports.StockProvider, repository ports.StocksRepository, workers int, batchSize int) *Service {
-	return &Service{
-		Provider:   provider,
-		Repository: repository,
-		Workers:    workers,
-		BatchSize:  batchSize,
+// noteUnknownRatings records the ratings of a normalized stock that did not
+// map onto the canonical scale.
+func (s *Service) noteUnknownRatings(stock domain.Stock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.unknownRatings == nil {
+		s.unknownRatings = make(map[string]bool)
 	}
+
+	if stock.RatingFromCanonical == nil && strings.TrimSpace(stock.RatingFrom) != "" {
+		s.unknownRatings[stock.RatingFrom] = true
+	}
+	if stock.RatingToCanonical == nil && strings.TrimSpace(stock.RatingTo) != "" {
+		s.unknownRatings[stock.RatingTo] = true
+	}
+}
+
+// unknownRatingsSeen lists the unknown ratings of the run, sorted.
+func (s *Service) unknownRatingsSeen() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ratings := make([]string, 0, len(s.unknownRatings))
+	for rating := range s.unknownRatings {
+		ratings = append(ratings, rating)
+	}
+	slices.Sort(ratings)
+
+	return ratings
+}
+
+func (s *Service) limiterWait() time.Duration {
+	limited, ok := s.Provider.(ports.RateLimitedProvider)
+	if !ok {
+		return 0
+	}
+
+	return limited.LimiterWait()
 }
@@ -1,6 +1,7 @@
 import { ApiURL, TopUrl } from '../config/config';
 import type { Stock } from '../models/stock.model';
 import type { StocksResponse } from '../models/stocksResponse.model';
+import type { Timeseries, TimeseriesBucket, TimeseriesGroupBy } from '../models/timeseries.model';
 
 export type StocksFilter = 'all' | 'up' | 'down' | 'equal'
 
@@ -33,4 +34,26 @@ export async function fetchTopStocks(): Promise<Stock[]> {
     }
     const data: Stock[] = await response.json();
     return data;
+}
+
+export async function fetchTimeseries(options?: {
+    bucket?: TimeseriesBucket
+    groupBy?: TimeseriesGroupBy | null
+    from?: string | null
+    to?: string | null
+}): Promise<Timeseries> {
+    // ApiURL points at /api/v1/stocks; the series live next to it.
+    const url = new URL('stats/timeseries', ApiURL)
+
+    url.searchParams.set('bucket', options?.bucket ?? 'day')
+    if (options?.groupBy) url.searchParams.set('group_by', options.groupBy)
+    if (options?.from) url.searchParams.set('from', options.from)
+    if (options?.to) url.searchParams.set('to', options.to)
+
+    const response = await fetch(url);
+    if (!response.ok) {
+        throw new Error(`Failed to fetch time series: ${response.status}`)
+    }
+    const data: Timeseries = await response.json();
+    return data;
 }
\ No newline at end of file
@@ -4,8 +4,8 @@ import type { CardProps } from '../models/cardProps.model';
 export function stockToCard(stock: Stock): CardProps {
   return {
     ticker: stock.ticker,
-    targetFrom: stock.target_from,
-    targetTo: stock.target_to,
+    targetFrom: stock.target_from_raw,
+    targetTo: stock.target_to_raw,
     company: stock.company,
     action: stock.rating_to
   }
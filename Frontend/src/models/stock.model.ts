@@ -1,11 +1,16 @@
 export interface Stock {
   ticker: string
-  target_from: string
-  target_to: string
+  target_from: number | null
+  target_to: number | null
+  target_from_raw: string
+  target_to_raw: string
+  target_change: number | null
   company: string
   action: string
   brokerage: string
   rating_from: string
   rating_to: string
+  rating_from_canonical: string | null
+  rating_to_canonical: string | null
   time: string
 }
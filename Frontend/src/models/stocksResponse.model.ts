@@ -12,4 +12,6 @@ export interface StocksResponse {
   items: Stock[]
   stats: StocksStats
   next_cursor?: string | null
+  prev_cursor?: string | null
+  has_more: boolean
 }
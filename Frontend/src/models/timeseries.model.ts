@@ -0,0 +1,22 @@
+export type TimeseriesBucket = 'day' | 'week' | 'month'
+export type TimeseriesGroupBy = 'brokerage' | 'ticker'
+
+export interface TimeseriesPoint {
+  bucket: string
+  actions: number
+  upgrades: number
+  downgrades: number
+  initiations: number
+  avg_target_change: number | null
+}
+
+export interface TimeseriesSeries {
+  key?: string
+  points: TimeseriesPoint[]
+}
+
+export interface Timeseries {
+  bucket: TimeseriesBucket
+  group_by?: TimeseriesGroupBy
+  series: TimeseriesSeries[]
+}
@@ -18,6 +18,7 @@ export const useStockStore = defineStore('stock', {
     serverStats: null as StocksStats | null,
     serverTotalPages: 0,
     nextCursor: null as string | null,
+    hasMore: false,
     pageCursors: { 1: null as string | null } as Record<number, string | null>,
 
     filter: 'all' as StockFilter,
@@ -133,6 +134,7 @@ export const useStockStore = defineStore('stock', {
         this.serverStats = resp.stats
         this.serverTotalPages = resp.stats.pages
         this.nextCursor = resp.next_cursor && resp.next_cursor.length > 0 ? resp.next_cursor : null
+        this.hasMore = resp.has_more
       } catch {
         this.error = 'Error cargando stocks'
       } finally {
@@ -172,6 +174,7 @@ export const useStockStore = defineStore('stock', {
         this.serverStats = resp.stats
         this.serverTotalPages = resp.stats.pages
         this.nextCursor = resp.next_cursor && resp.next_cursor.length > 0 ? resp.next_cursor : null
+        this.hasMore = resp.has_more
       } catch {
         this.error = 'Error cargando stock por ticker'
       } finally {
@@ -198,7 +201,7 @@ export const useStockStore = defineStore('stock', {
     },
 
     async nextPage() {
-      if (this.currentPage >= this.totalPages) return
+      if (this.currentPage >= this.totalPages || !this.hasMore) return
       if (this.loading) return
 
       const nextPageNum = this.currentPage + 1
@@ -219,6 +222,7 @@ export const useStockStore = defineStore('stock', {
         this.serverStats = resp.stats
         this.serverTotalPages = resp.stats.pages
         this.nextCursor = resp.next_cursor && resp.next_cursor.length > 0 ? resp.next_cursor : null
+        this.hasMore = resp.has_more
       } catch {
         this.error = 'Error cargando stocks'
       } finally {
@@ -246,6 +250,7 @@ export const useStockStore = defineStore('stock', {
         this.serverStats = resp.stats
         this.serverTotalPages = resp.stats.pages
         this.nextCursor = resp.next_cursor && resp.next_cursor.length > 0 ? resp.next_cursor : null
+        this.hasMore = resp.has_more
       } catch {
         this.error = 'Error cargando stocks'
       } finally {